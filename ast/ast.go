@@ -2,6 +2,7 @@ package ast
 
 import (
 	"bytes"
+	"strings"
 
 	"github.com/mycok/monkey_interpreter/token"
 )
@@ -10,6 +11,8 @@ import (
 type Node interface {
 	TokenLiteral() string
 	String() string
+	Pos() token.Pos // Position of the first character belonging to the node.
+	End() token.Pos // Position immediately after the last character belonging to the node.
 }
 
 // Statement interface is implemented by statement types.
@@ -39,6 +42,24 @@ func (p *Program) TokenLiteral() string {
 	return ""
 }
 
+// Pos returns the position of the first statement of the program.
+func (p *Program) Pos() token.Pos {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+
+	return token.Pos{}
+}
+
+// End returns the position immediately after the last statement of the program.
+func (p *Program) End() token.Pos {
+	if n := len(p.Statements); n > 0 {
+		return p.Statements[n-1].End()
+	}
+
+	return token.Pos{}
+}
+
 // String returns the string representation of p.statements slice.
 func (p *Program) String() string {
 	var out bytes.Buffer
@@ -60,6 +81,22 @@ type LetStatement struct {
 // TokenLiteral returns a token literal value of the token.
 func (ls *LetStatement) TokenLiteral() string { return ls.Token.Literal }
 
+// Pos returns the position of the first character of the LetStatement.
+func (ls *LetStatement) Pos() token.Pos { return ls.Token.Pos }
+
+// End returns the position immediately after the last character of the LetStatement.
+func (ls *LetStatement) End() token.Pos {
+	if ls.Value != nil {
+		return ls.Value.End()
+	}
+
+	if ls.Name != nil {
+		return ls.Name.End()
+	}
+
+	return ls.Token.End()
+}
+
 // String returns the string representation of the LetStatement type.
 func (ls *LetStatement) String() string {
 	var out bytes.Buffer
@@ -88,6 +125,18 @@ type ReturnStatement struct {
 // TokenLiteral returns a token literal value of the token.
 func (rs *ReturnStatement) TokenLiteral() string { return rs.Token.Literal }
 
+// Pos returns the position of the first character of the ReturnStatement.
+func (rs *ReturnStatement) Pos() token.Pos { return rs.Token.Pos }
+
+// End returns the position immediately after the last character of the ReturnStatement.
+func (rs *ReturnStatement) End() token.Pos {
+	if rs.ReturnValue != nil {
+		return rs.ReturnValue.End()
+	}
+
+	return rs.Token.End()
+}
+
 // String returns the string representation of the ReturnStatement type.
 func (rs *ReturnStatement) String() string {
 	var out bytes.Buffer
@@ -105,6 +154,110 @@ func (rs *ReturnStatement) String() string {
 
 func (rs *ReturnStatement) statementNode() {}
 
+// WhileStatement represents a loop such as (while (x < 10) { x += 1 }) that evaluates
+// its body for as long as the condition is truthy.
+type WhileStatement struct {
+	Token     token.Token // The while token.
+	Condition Expression
+	Body      *BlockStatement
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (ws *WhileStatement) TokenLiteral() string { return ws.Token.Literal }
+
+// Pos returns the position of the first character of the WhileStatement.
+func (ws *WhileStatement) Pos() token.Pos { return ws.Token.Pos }
+
+// End returns the position immediately after the last character of the WhileStatement.
+func (ws *WhileStatement) End() token.Pos { return ws.Body.End() }
+
+// String returns the string representation of the WhileStatement type.
+func (ws *WhileStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("while")
+	out.WriteString(ws.Condition.String())
+	out.WriteString(" ")
+	out.WriteString(ws.Body.String())
+
+	return out.String()
+}
+
+func (ws *WhileStatement) statementNode() {}
+
+// ForStatement represents a loop such as (for (x in [1, 2, 3]) { puts(x) }) that
+// evaluates its body once for every element of an iterable value.
+type ForStatement struct {
+	Token    token.Token // The for token.
+	Variable *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (fs *ForStatement) TokenLiteral() string { return fs.Token.Literal }
+
+// Pos returns the position of the first character of the ForStatement.
+func (fs *ForStatement) Pos() token.Pos { return fs.Token.Pos }
+
+// End returns the position immediately after the last character of the ForStatement.
+func (fs *ForStatement) End() token.Pos { return fs.Body.End() }
+
+// String returns the string representation of the ForStatement type.
+func (fs *ForStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("for(")
+	out.WriteString(fs.Variable.String())
+	out.WriteString(" in ")
+	out.WriteString(fs.Iterable.String())
+	out.WriteString(") ")
+	out.WriteString(fs.Body.String())
+
+	return out.String()
+}
+
+func (fs *ForStatement) statementNode() {}
+
+// BreakStatement represents a break statement that terminates the enclosing loop.
+type BreakStatement struct {
+	Token token.Token
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (bs *BreakStatement) TokenLiteral() string { return bs.Token.Literal }
+
+// Pos returns the position of the first character of the BreakStatement.
+func (bs *BreakStatement) Pos() token.Pos { return bs.Token.Pos }
+
+// End returns the position immediately after the last character of the BreakStatement.
+func (bs *BreakStatement) End() token.Pos { return bs.Token.End() }
+
+// String returns the string representation of the BreakStatement type.
+func (bs *BreakStatement) String() string { return bs.TokenLiteral() + ";" }
+
+func (bs *BreakStatement) statementNode() {}
+
+// ContinueStatement represents a continue statement that skips to the next iteration
+// of the enclosing loop.
+type ContinueStatement struct {
+	Token token.Token
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (cs *ContinueStatement) TokenLiteral() string { return cs.Token.Literal }
+
+// Pos returns the position of the first character of the ContinueStatement.
+func (cs *ContinueStatement) Pos() token.Pos { return cs.Token.Pos }
+
+// End returns the position immediately after the last character of the ContinueStatement.
+func (cs *ContinueStatement) End() token.Pos { return cs.Token.End() }
+
+// String returns the string representation of the ContinueStatement type.
+func (cs *ContinueStatement) String() string { return cs.TokenLiteral() + ";" }
+
+func (cs *ContinueStatement) statementNode() {}
+
 // ExpressionStatement represents an expression statement in a program. such as (x + 55).
 type ExpressionStatement struct {
 	Token      token.Token
@@ -114,6 +267,24 @@ type ExpressionStatement struct {
 // TokenLiteral returns a token literal value of the token.
 func (es *ExpressionStatement) TokenLiteral() string { return es.Token.Literal }
 
+// Pos returns the position of the first character of the ExpressionStatement.
+func (es *ExpressionStatement) Pos() token.Pos {
+	if es.Expression != nil {
+		return es.Expression.Pos()
+	}
+
+	return es.Token.Pos
+}
+
+// End returns the position immediately after the last character of the ExpressionStatement.
+func (es *ExpressionStatement) End() token.Pos {
+	if es.Expression != nil {
+		return es.Expression.End()
+	}
+
+	return es.Token.End()
+}
+
 // String returns the string representation of the ExpressionStatement type.
 func (es *ExpressionStatement) String() string {
 	if es.Expression != nil {
@@ -134,6 +305,12 @@ type Identifier struct {
 // TokenLiteral returns a token literal value of the token.
 func (i *Identifier) TokenLiteral() string { return i.Token.Literal }
 
+// Pos returns the position of the first character of the Identifier.
+func (i *Identifier) Pos() token.Pos { return i.Token.Pos }
+
+// End returns the position immediately after the last character of the Identifier.
+func (i *Identifier) End() token.Pos { return i.Token.End() }
+
 // String returns the Identifier.Value.
 func (i *Identifier) String() string { return i.Value }
 
@@ -148,11 +325,58 @@ type IntegerLiteral struct {
 // TokenLiteral returns a token literal value of the token.
 func (il *IntegerLiteral) TokenLiteral() string { return il.Token.Literal }
 
+// Pos returns the position of the first character of the IntegerLiteral.
+func (il *IntegerLiteral) Pos() token.Pos { return il.Token.Pos }
+
+// End returns the position immediately after the last character of the IntegerLiteral.
+func (il *IntegerLiteral) End() token.Pos { return il.Token.End() }
+
 // String returns a string representation of the IntegerLiteral type.
 func (il *IntegerLiteral) String() string { return il.Token.Literal }
 
 func (il *IntegerLiteral) expressionNode() {}
 
+// FloatLiteral represents a single float literal token. ie (3.14; or "1.5e-3;").
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (fl *FloatLiteral) TokenLiteral() string { return fl.Token.Literal }
+
+// Pos returns the position of the first character of the FloatLiteral.
+func (fl *FloatLiteral) Pos() token.Pos { return fl.Token.Pos }
+
+// End returns the position immediately after the last character of the FloatLiteral.
+func (fl *FloatLiteral) End() token.Pos { return fl.Token.End() }
+
+// String returns a string representation of the FloatLiteral type.
+func (fl *FloatLiteral) String() string { return fl.Token.Literal }
+
+func (fl *FloatLiteral) expressionNode() {}
+
+// StringLiteral represents a string literal such as ("foo\tbar"). Value holds the string
+// with its escape sequences interpreted while Token.Literal holds the quoted source text.
+type StringLiteral struct {
+	Token token.Token
+	Value string
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (sl *StringLiteral) TokenLiteral() string { return sl.Token.Literal }
+
+// Pos returns the position of the first character of the StringLiteral.
+func (sl *StringLiteral) Pos() token.Pos { return sl.Token.Pos }
+
+// End returns the position immediately after the last character of the StringLiteral.
+func (sl *StringLiteral) End() token.Pos { return sl.Token.End() }
+
+// String returns a string representation of the StringLiteral type.
+func (sl *StringLiteral) String() string { return sl.Token.Literal }
+
+func (sl *StringLiteral) expressionNode() {}
+
 // PrefixExpression represents an expression such as (!4, +3, -19).
 type PrefixExpression struct {
 	Token    token.Token
@@ -163,6 +387,18 @@ type PrefixExpression struct {
 // TokenLiteral returns a token literal value of the token.
 func (pe *PrefixExpression) TokenLiteral() string { return pe.Token.Literal }
 
+// Pos returns the position of the first character of the PrefixExpression.
+func (pe *PrefixExpression) Pos() token.Pos { return pe.Token.Pos }
+
+// End returns the position immediately after the last character of the PrefixExpression.
+func (pe *PrefixExpression) End() token.Pos {
+	if pe.Right != nil {
+		return pe.Right.End()
+	}
+
+	return pe.Token.End()
+}
+
 // String returns a string representation of the PrefixExpression type.
 func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
@@ -188,6 +424,24 @@ type InfixExpression struct {
 // TokenLiteral returns a token literal value of the token.
 func (ie *InfixExpression) TokenLiteral() string { return ie.Token.Literal }
 
+// Pos returns the position of the first character of the InfixExpression.
+func (ie *InfixExpression) Pos() token.Pos {
+	if ie.Left != nil {
+		return ie.Left.Pos()
+	}
+
+	return ie.Token.Pos
+}
+
+// End returns the position immediately after the last character of the InfixExpression.
+func (ie *InfixExpression) End() token.Pos {
+	if ie.Right != nil {
+		return ie.Right.End()
+	}
+
+	return ie.Token.End()
+}
+
 // String returns a string representation of the InfixExpression type.
 func (ie *InfixExpression) String() string {
 	var out bytes.Buffer
@@ -202,3 +456,456 @@ func (ie *InfixExpression) String() string {
 }
 
 func (ie *InfixExpression) expressionNode() {}
+
+// ParenExpression represents a parenthesised expression such as ((1 + 2)).
+type ParenExpression struct {
+	Token      token.Token // The ( token.
+	Expression Expression
+	Rparen     token.Pos // Position of the closing ).
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (pe *ParenExpression) TokenLiteral() string { return pe.Token.Literal }
+
+// Pos returns the position of the first character of the ParenExpression.
+func (pe *ParenExpression) Pos() token.Pos { return pe.Token.Pos }
+
+// End returns the position immediately after the last character of the ParenExpression.
+func (pe *ParenExpression) End() token.Pos { return pe.Rparen.Advance(")") }
+
+// String returns a string representation of the ParenExpression type. The parentheses
+// are omitted since String already groups every operator explicitly.
+func (pe *ParenExpression) String() string { return pe.Expression.String() }
+
+func (pe *ParenExpression) expressionNode() {}
+
+// Boolean represents a boolean literal such as (true or false).
+type Boolean struct {
+	Token token.Token
+	Value bool
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (b *Boolean) TokenLiteral() string { return b.Token.Literal }
+
+// Pos returns the position of the first character of the Boolean.
+func (b *Boolean) Pos() token.Pos { return b.Token.Pos }
+
+// End returns the position immediately after the last character of the Boolean.
+func (b *Boolean) End() token.Pos { return b.Token.End() }
+
+// String returns a string representation of the Boolean type.
+func (b *Boolean) String() string { return b.Token.Literal }
+
+func (b *Boolean) expressionNode() {}
+
+// BlockStatement represents a series of statements enclosed within braces. ie ({ x + 5; }).
+type BlockStatement struct {
+	Token      token.Token // The { token.
+	Statements []Statement
+	Rbrace     token.Pos // Position of the closing }.
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (bs *BlockStatement) TokenLiteral() string { return bs.Token.Literal }
+
+// Pos returns the position of the first character of the BlockStatement.
+func (bs *BlockStatement) Pos() token.Pos { return bs.Token.Pos }
+
+// End returns the position immediately after the last character of the BlockStatement.
+func (bs *BlockStatement) End() token.Pos { return bs.Rbrace.Advance("}") }
+
+// String returns a string representation of the BlockStatement type.
+func (bs *BlockStatement) String() string {
+	var out bytes.Buffer
+
+	for _, s := range bs.Statements {
+		out.WriteString(s.String())
+	}
+
+	return out.String()
+}
+
+func (bs *BlockStatement) statementNode() {}
+
+// IfExpression represents a conditional expression such as
+// (if (x < y) { x } elseif (x > y) { y } else { z }).
+type IfExpression struct {
+	Token       token.Token // The if token.
+	Condition   Expression
+	Consequence *BlockStatement
+	ElseIfs     []*ElseIfBranch
+	Alternative *BlockStatement
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (ie *IfExpression) TokenLiteral() string { return ie.Token.Literal }
+
+// Pos returns the position of the first character of the IfExpression.
+func (ie *IfExpression) Pos() token.Pos { return ie.Token.Pos }
+
+// End returns the position immediately after the last character of the IfExpression.
+func (ie *IfExpression) End() token.Pos {
+	if ie.Alternative != nil {
+		return ie.Alternative.End()
+	}
+
+	if n := len(ie.ElseIfs); n > 0 {
+		return ie.ElseIfs[n-1].End()
+	}
+
+	return ie.Consequence.End()
+}
+
+// String returns a string representation of the IfExpression type.
+func (ie *IfExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("if")
+	out.WriteString(ie.Condition.String())
+	out.WriteString(" ")
+	out.WriteString(ie.Consequence.String())
+
+	for _, branch := range ie.ElseIfs {
+		out.WriteString(" ")
+		out.WriteString(branch.String())
+	}
+
+	if ie.Alternative != nil {
+		out.WriteString(" else ")
+		out.WriteString(ie.Alternative.String())
+	}
+
+	return out.String()
+}
+
+func (ie *IfExpression) expressionNode() {}
+
+// ElseIfBranch represents a single elseif branch of an IfExpression.
+type ElseIfBranch struct {
+	Token       token.Token // The elseif token.
+	Condition   Expression
+	Consequence *BlockStatement
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (eb *ElseIfBranch) TokenLiteral() string { return eb.Token.Literal }
+
+// Pos returns the position of the first character of the ElseIfBranch.
+func (eb *ElseIfBranch) Pos() token.Pos { return eb.Token.Pos }
+
+// End returns the position immediately after the last character of the ElseIfBranch.
+func (eb *ElseIfBranch) End() token.Pos { return eb.Consequence.End() }
+
+// String returns a string representation of the ElseIfBranch type.
+func (eb *ElseIfBranch) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("elseif")
+	out.WriteString(eb.Condition.String())
+	out.WriteString(" ")
+	out.WriteString(eb.Consequence.String())
+
+	return out.String()
+}
+
+// AssignExpression represents an assignment such as (x = 5), (x += 1) or (arr[0] = 1).
+type AssignExpression struct {
+	Token    token.Token // The assignment operator token.
+	Target   Expression  // Either an *Identifier or an *IndexExpression.
+	Operator string
+	Value    Expression
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (ae *AssignExpression) TokenLiteral() string { return ae.Token.Literal }
+
+// Pos returns the position of the first character of the AssignExpression.
+func (ae *AssignExpression) Pos() token.Pos {
+	if ae.Target != nil {
+		return ae.Target.Pos()
+	}
+
+	return ae.Token.Pos
+}
+
+// End returns the position immediately after the last character of the AssignExpression.
+func (ae *AssignExpression) End() token.Pos {
+	if ae.Value != nil {
+		return ae.Value.End()
+	}
+
+	return ae.Token.End()
+}
+
+// String returns a string representation of the AssignExpression type.
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ae.Target.String())
+	out.WriteString(" " + ae.Operator + " ")
+	out.WriteString(ae.Value.String())
+	out.WriteString(")")
+
+	return out.String()
+}
+
+func (ae *AssignExpression) expressionNode() {}
+
+// FunctionLiteral represents a function definition such as (fn(x, y) { x + y; }).
+type FunctionLiteral struct {
+	Token      token.Token // The fn token.
+	Parameters []*Identifier
+	Body       *BlockStatement
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (fl *FunctionLiteral) TokenLiteral() string { return fl.Token.Literal }
+
+// Pos returns the position of the first character of the FunctionLiteral.
+func (fl *FunctionLiteral) Pos() token.Pos { return fl.Token.Pos }
+
+// End returns the position immediately after the last character of the FunctionLiteral.
+func (fl *FunctionLiteral) End() token.Pos { return fl.Body.End() }
+
+// String returns a string representation of the FunctionLiteral type.
+func (fl *FunctionLiteral) String() string {
+	var out bytes.Buffer
+
+	params := make([]string, 0, len(fl.Parameters))
+	for _, p := range fl.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString(fl.TokenLiteral())
+	out.WriteString("(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") ")
+	out.WriteString(fl.Body.String())
+
+	return out.String()
+}
+
+func (fl *FunctionLiteral) expressionNode() {}
+
+// CallExpression represents a function call such as (add(2, 3)).
+type CallExpression struct {
+	Token     token.Token // The ( token.
+	Function  Expression  // Identifier or FunctionLiteral.
+	Arguments []Expression
+	Rparen    token.Pos // Position of the closing ).
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (ce *CallExpression) TokenLiteral() string { return ce.Token.Literal }
+
+// Pos returns the position of the first character of the CallExpression.
+func (ce *CallExpression) Pos() token.Pos {
+	if ce.Function != nil {
+		return ce.Function.Pos()
+	}
+
+	return ce.Token.Pos
+}
+
+// End returns the position immediately after the last character of the CallExpression.
+func (ce *CallExpression) End() token.Pos { return ce.Rparen.Advance(")") }
+
+// String returns a string representation of the CallExpression type.
+func (ce *CallExpression) String() string {
+	var out bytes.Buffer
+
+	args := make([]string, 0, len(ce.Arguments))
+	for _, a := range ce.Arguments {
+		args = append(args, a.String())
+	}
+
+	out.WriteString(ce.Function.String())
+	out.WriteString("(")
+	out.WriteString(strings.Join(args, ", "))
+	out.WriteString(")")
+
+	return out.String()
+}
+
+func (ce *CallExpression) expressionNode() {}
+
+// ArrayLiteral represents an array literal such as ([1, 2 * 2, "three"]).
+type ArrayLiteral struct {
+	Token    token.Token // The [ token.
+	Elements []Expression
+	Rbracket token.Pos // Position of the closing ].
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (al *ArrayLiteral) TokenLiteral() string { return al.Token.Literal }
+
+// Pos returns the position of the first character of the ArrayLiteral.
+func (al *ArrayLiteral) Pos() token.Pos { return al.Token.Pos }
+
+// End returns the position immediately after the last character of the ArrayLiteral.
+func (al *ArrayLiteral) End() token.Pos { return al.Rbracket.Advance("]") }
+
+// String returns a string representation of the ArrayLiteral type.
+func (al *ArrayLiteral) String() string {
+	var out bytes.Buffer
+
+	elements := make([]string, 0, len(al.Elements))
+	for _, el := range al.Elements {
+		elements = append(elements, el.String())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+func (al *ArrayLiteral) expressionNode() {}
+
+// IndexExpression represents an index operation such as (myArray[1] or myHash["key"]).
+type IndexExpression struct {
+	Token    token.Token // The [ token.
+	Left     Expression
+	Index    Expression
+	Rbracket token.Pos // Position of the closing ].
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (ie *IndexExpression) TokenLiteral() string { return ie.Token.Literal }
+
+// Pos returns the position of the first character of the IndexExpression.
+func (ie *IndexExpression) Pos() token.Pos {
+	if ie.Left != nil {
+		return ie.Left.Pos()
+	}
+
+	return ie.Token.Pos
+}
+
+// End returns the position immediately after the last character of the IndexExpression.
+func (ie *IndexExpression) End() token.Pos { return ie.Rbracket.Advance("]") }
+
+// String returns a string representation of the IndexExpression type.
+func (ie *IndexExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ie.Left.String())
+	out.WriteString("[")
+	out.WriteString(ie.Index.String())
+	out.WriteString("])")
+
+	return out.String()
+}
+
+func (ie *IndexExpression) expressionNode() {}
+
+// HashLiteral represents a hash map literal such as ({"one": 1, "two": 2}). The pairs
+// are kept in source order.
+type HashLiteral struct {
+	Token  token.Token // The { token.
+	Pairs  []*HashPair
+	Rbrace token.Pos // Position of the closing }.
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (hl *HashLiteral) TokenLiteral() string { return hl.Token.Literal }
+
+// Pos returns the position of the first character of the HashLiteral.
+func (hl *HashLiteral) Pos() token.Pos { return hl.Token.Pos }
+
+// End returns the position immediately after the last character of the HashLiteral.
+func (hl *HashLiteral) End() token.Pos { return hl.Rbrace.Advance("}") }
+
+// String returns a string representation of the HashLiteral type.
+func (hl *HashLiteral) String() string {
+	var out bytes.Buffer
+
+	pairs := make([]string, 0, len(hl.Pairs))
+	for _, pair := range hl.Pairs {
+		pairs = append(pairs, pair.String())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+func (hl *HashLiteral) expressionNode() {}
+
+// HashPair represents a single key: value pair of a HashLiteral.
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+// TokenLiteral returns a token literal value of the key token.
+func (hp *HashPair) TokenLiteral() string { return hp.Key.TokenLiteral() }
+
+// Pos returns the position of the first character of the HashPair.
+func (hp *HashPair) Pos() token.Pos { return hp.Key.Pos() }
+
+// End returns the position immediately after the last character of the HashPair.
+func (hp *HashPair) End() token.Pos { return hp.Value.End() }
+
+// String returns a string representation of the HashPair type.
+func (hp *HashPair) String() string { return hp.Key.String() + ": " + hp.Value.String() }
+
+// BadStatement is a placeholder for a statement that contains a syntax error and could
+// not be parsed.
+type BadStatement struct {
+	Token token.Token // The first token of the statement.
+	To    token.Pos   // Position immediately after the last skipped token.
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (bs *BadStatement) TokenLiteral() string { return bs.Token.Literal }
+
+// Pos returns the position of the first character of the BadStatement.
+func (bs *BadStatement) Pos() token.Pos { return bs.Token.Pos }
+
+// End returns the position immediately after the last character of the BadStatement.
+func (bs *BadStatement) End() token.Pos { return bs.To }
+
+// String returns the string representation of the BadStatement type.
+func (bs *BadStatement) String() string { return "<bad statement>" }
+
+func (bs *BadStatement) statementNode() {}
+
+// BadExpression is a placeholder for an expression that contains a syntax error and
+// could not be parsed.
+type BadExpression struct {
+	Token token.Token // The first token of the expression.
+	To    token.Pos   // Position immediately after the last consumed token.
+}
+
+// TokenLiteral returns a token literal value of the token.
+func (be *BadExpression) TokenLiteral() string { return be.Token.Literal }
+
+// Pos returns the position of the first character of the BadExpression.
+func (be *BadExpression) Pos() token.Pos { return be.Token.Pos }
+
+// End returns the position immediately after the last character of the BadExpression.
+func (be *BadExpression) End() token.Pos { return be.To }
+
+// String returns the string representation of the BadExpression type.
+func (be *BadExpression) String() string { return "<bad expression>" }
+
+func (be *BadExpression) expressionNode() {}
+
+// Unparen returns the expression with any enclosing parentheses removed.
+func Unparen(e Expression) Expression {
+	for {
+		paren, ok := e.(*ParenExpression)
+		if !ok {
+			return e
+		}
+
+		e = paren.Expression
+	}
+}
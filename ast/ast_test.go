@@ -0,0 +1,60 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/mycok/monkey_interpreter/ast"
+	"github.com/mycok/monkey_interpreter/token"
+)
+
+func TestSpansOfIncompleteNodes(t *testing.T) {
+	at := func(offset int, tokenType token.TokenType, literal string) token.Token {
+		return token.Token{Type: tokenType, Literal: literal, Pos: token.Pos{Offset: offset, Line: 1, Column: offset + 1, RuneColumn: offset + 1}}
+	}
+
+	name := &ast.Identifier{Token: at(4, token.IDENT, "x"), Value: "x"}
+
+	tests := []struct {
+		node        ast.Node
+		expectedPos int
+		expectedEnd int
+	}{
+		{&ast.LetStatement{Token: at(0, token.LET, "let"), Name: name}, 0, 5},
+		{&ast.LetStatement{Token: at(0, token.LET, "let")}, 0, 3},
+		{&ast.ExpressionStatement{Token: at(2, token.IDENT, "y")}, 2, 3},
+		{&ast.PrefixExpression{Token: at(0, token.MINUS, "-"), Operator: "-"}, 0, 1},
+		{&ast.InfixExpression{Token: at(2, token.PLUS, "+"), Operator: "+"}, 2, 3},
+		{&ast.AssignExpression{Token: at(2, token.ASSIGN, "="), Operator: "="}, 2, 3},
+	}
+
+	for i, tc := range tests {
+		if pos := tc.node.Pos(); pos.Offset != tc.expectedPos {
+			t.Errorf("tests[%d] - Pos().Offset is not %d. got: %d instead", i, tc.expectedPos, pos.Offset)
+		}
+
+		if end := tc.node.End(); end.Offset != tc.expectedEnd {
+			t.Errorf("tests[%d] - End().Offset is not %d. got: %d instead", i, tc.expectedEnd, end.Offset)
+		}
+	}
+}
+
+func TestApplyReplaceWithIncompleteNode(t *testing.T) {
+	program := parseProgram(t, "a;")
+
+	result := ast.Apply(program, func(c *ast.Cursor) bool {
+		if _, ok := c.Node().(*ast.ExpressionStatement); ok {
+			c.Replace(&ast.ExpressionStatement{})
+		}
+
+		return true
+	}, nil)
+
+	stmt, ok := result.(*ast.Program).Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Statements[0] is not *ast.ExpressionStatement. got: %T instead", result.(*ast.Program).Statements[0])
+	}
+
+	if stmt.Pos().Line != 1 || stmt.Pos().Column != 1 {
+		t.Errorf("replacement did not inherit the position of the original statement. got: %s instead", stmt.Pos())
+	}
+}
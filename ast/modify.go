@@ -0,0 +1,22 @@
+package ast
+
+// ModifierFunc returns the node that should take the place of node in the tree. It may
+// return node itself to leave it unchanged.
+type ModifierFunc func(node Node) Node
+
+// Modify traverses the tree rooted at node in post-order and replaces every node with
+// the result of calling modifier on it, so that the children of a node are modified
+// before the node itself. The modified tree is returned.
+//
+// A replacement must fit the field that holds the original node, such as an expression
+// in place of an expression. Modify panics if modifier returns nil. Replacements without
+// a valid position inherit the position of the node they replace where possible.
+func Modify(node Node, modifier ModifierFunc) Node {
+	return Apply(node, nil, func(c *Cursor) bool {
+		if modified := modifier(c.Node()); modified != c.Node() {
+			c.Replace(modified)
+		}
+
+		return true
+	})
+}
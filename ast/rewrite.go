@@ -0,0 +1,354 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/mycok/monkey_interpreter/token"
+)
+
+// ApplyFunc is invoked by Apply for each non-nil node n before and/or after the node's
+// children, using a Cursor describing the current node and providing operations on it.
+//
+// The return value of ApplyFunc controls the syntax tree traversal. See Apply for details.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses a syntax tree recursively, starting with root, and calling pre and
+// post for each node as described below. Apply returns the syntax tree, possibly
+// modified.
+//
+// If pre is not nil, it is called for each node before the node's children are
+// traversed (pre-order). If pre returns false, no children are traversed, and post is
+// not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false, post is called for
+// each node after its children are traversed (post-order). If post returns false,
+// traversal is terminated and Apply returns immediately.
+//
+// Only fields that refer to AST nodes are considered children; nil children such as the
+// value of a bare return statement are skipped. Children are traversed in the same
+// order as by Walk.
+//
+// Nodes that are replaced by pre are traversed instead of the original node. Nodes that
+// are inserted before the current node of a statement list are not traversed.
+func Apply(root Node, pre, post ApplyFunc) (result Node) {
+	a := &application{pre: pre, post: post}
+
+	result = root
+	a.apply(nil, "Node", root, func(n Node) { result = n })
+
+	return result
+}
+
+// A Cursor describes a node encountered during Apply. Information about the node and
+// its parent is available from the Node, Parent, Name and Index methods.
+//
+// The methods Replace, Delete and InsertBefore can be used to change the AST without
+// disrupting Apply. Delete and InsertBefore are only supported on statement lists.
+type Cursor struct {
+	parent Node
+	name   string
+	node   Node
+	set    func(Node)
+	index  int          // Index of node in the enclosing list, -1 otherwise.
+	list   *[]Statement // Statement list containing node, nil otherwise.
+	iter   *iterator
+}
+
+// Node returns the current Node.
+func (c *Cursor) Node() Node { return c.node }
+
+// Parent returns the parent of the current Node.
+func (c *Cursor) Parent() Node { return c.parent }
+
+// Name returns the name of the parent Node field that contains the current Node. If the
+// parent is a list, Name returns the name of the list field.
+func (c *Cursor) Name() string { return c.name }
+
+// Index reports the index of the current Node in the list that contains it, or a value
+// < 0 if the current Node is not part of a list.
+func (c *Cursor) Index() int {
+	if c.iter != nil {
+		return c.iter.index
+	}
+
+	return c.index
+}
+
+// Replace replaces the current Node with n. A node replaced in pre is traversed instead
+// of the current Node, while a node replaced in post is not traversed by Apply. When n
+// has no valid position, it inherits the position of the current Node where possible.
+// Replace panics if n can not be stored in the parent field, such as an expression in
+// place of a statement, or if n is nil.
+func (c *Cursor) Replace(n Node) {
+	if n == nil {
+		panic("ast.Cursor.Replace: nil node; use Delete to remove a statement")
+	}
+
+	if !n.Pos().IsValid() && c.node != nil {
+		setPos(n, c.node.Pos())
+	}
+
+	c.set(n)
+	c.node = n
+}
+
+// Delete deletes the current Node from its containing statement list. If the current
+// Node is not part of a statement list, Delete panics.
+func (c *Cursor) Delete() {
+	if c.list == nil {
+		panic("ast.Cursor.Delete: node not contained in a statement list")
+	}
+
+	i := c.iter.index
+	*c.list = append((*c.list)[:i], (*c.list)[i+1:]...)
+
+	c.iter.step--
+}
+
+// InsertBefore inserts n before the current Node in its containing statement list. If
+// the current Node is not part of a statement list, InsertBefore panics. Apply does not
+// traverse n.
+func (c *Cursor) InsertBefore(n Statement) {
+	if c.list == nil {
+		panic("ast.Cursor.InsertBefore: node not contained in a statement list")
+	}
+
+	if !n.Pos().IsValid() {
+		setPos(n, c.node.Pos())
+	}
+
+	i := c.iter.index
+	*c.list = append((*c.list)[:i], append([]Statement{n}, (*c.list)[i:]...)...)
+
+	c.iter.index++
+}
+
+// iterator keeps track of the position of the current node within a statement list
+// that may be modified during the traversal.
+type iterator struct {
+	index, step int
+}
+
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+	iter      iterator
+	stopped   bool
+}
+
+func (a *application) apply(parent Node, name string, n Node, set func(Node)) {
+	a.applyAt(Cursor{parent: parent, name: name, node: n, set: set, index: -1})
+}
+
+func (a *application) applyAt(c Cursor) {
+	if a.stopped {
+		return
+	}
+
+	saved := a.cursor
+	a.cursor = c
+
+	defer func() { a.cursor = saved }()
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		return
+	}
+
+	switch n := a.cursor.node.(type) {
+	// Statements
+	case *Program:
+		a.applyStatements(n, "Statements", &n.Statements)
+	case *LetStatement:
+		a.apply(n, "Name", n.Name, func(r Node) { n.Name = r.(*Identifier) })
+		a.applyExpression(n, "Value", n.Value, func(r Node) { n.Value = r.(Expression) })
+	case *ReturnStatement:
+		a.applyExpression(n, "ReturnValue", n.ReturnValue, func(r Node) { n.ReturnValue = r.(Expression) })
+	case *ExpressionStatement:
+		a.applyExpression(n, "Expression", n.Expression, func(r Node) { n.Expression = r.(Expression) })
+	case *BlockStatement:
+		a.applyStatements(n, "Statements", &n.Statements)
+	case *WhileStatement:
+		a.applyExpression(n, "Condition", n.Condition, func(r Node) { n.Condition = r.(Expression) })
+		a.apply(n, "Body", n.Body, func(r Node) { n.Body = r.(*BlockStatement) })
+	case *ForStatement:
+		a.apply(n, "Variable", n.Variable, func(r Node) { n.Variable = r.(*Identifier) })
+		a.applyExpression(n, "Iterable", n.Iterable, func(r Node) { n.Iterable = r.(Expression) })
+		a.apply(n, "Body", n.Body, func(r Node) { n.Body = r.(*BlockStatement) })
+	case *BreakStatement, *ContinueStatement, *BadStatement:
+		// Nothing to do.
+
+	// Expressions
+	case *Identifier, *IntegerLiteral, *FloatLiteral, *StringLiteral, *Boolean, *BadExpression:
+		// Nothing to do.
+	case *PrefixExpression:
+		a.applyExpression(n, "Right", n.Right, func(r Node) { n.Right = r.(Expression) })
+	case *InfixExpression:
+		a.applyExpression(n, "Left", n.Left, func(r Node) { n.Left = r.(Expression) })
+		a.applyExpression(n, "Right", n.Right, func(r Node) { n.Right = r.(Expression) })
+	case *ParenExpression:
+		a.applyExpression(n, "Expression", n.Expression, func(r Node) { n.Expression = r.(Expression) })
+	case *AssignExpression:
+		a.applyExpression(n, "Target", n.Target, func(r Node) { n.Target = r.(Expression) })
+		a.applyExpression(n, "Value", n.Value, func(r Node) { n.Value = r.(Expression) })
+	case *IfExpression:
+		a.applyExpression(n, "Condition", n.Condition, func(r Node) { n.Condition = r.(Expression) })
+		a.apply(n, "Consequence", n.Consequence, func(r Node) { n.Consequence = r.(*BlockStatement) })
+
+		for i := range n.ElseIfs {
+			i := i
+			a.applyElement(n, "ElseIfs", i, n.ElseIfs[i], func(r Node) { n.ElseIfs[i] = r.(*ElseIfBranch) })
+		}
+
+		if n.Alternative != nil {
+			a.apply(n, "Alternative", n.Alternative, func(r Node) { n.Alternative = r.(*BlockStatement) })
+		}
+	case *ElseIfBranch:
+		a.applyExpression(n, "Condition", n.Condition, func(r Node) { n.Condition = r.(Expression) })
+		a.apply(n, "Consequence", n.Consequence, func(r Node) { n.Consequence = r.(*BlockStatement) })
+	case *FunctionLiteral:
+		for i := range n.Parameters {
+			i := i
+			a.applyElement(n, "Parameters", i, n.Parameters[i], func(r Node) { n.Parameters[i] = r.(*Identifier) })
+		}
+
+		a.apply(n, "Body", n.Body, func(r Node) { n.Body = r.(*BlockStatement) })
+	case *CallExpression:
+		a.applyExpression(n, "Function", n.Function, func(r Node) { n.Function = r.(Expression) })
+		a.applyExpressions(n, "Arguments", n.Arguments)
+	case *ArrayLiteral:
+		a.applyExpressions(n, "Elements", n.Elements)
+	case *IndexExpression:
+		a.applyExpression(n, "Left", n.Left, func(r Node) { n.Left = r.(Expression) })
+		a.applyExpression(n, "Index", n.Index, func(r Node) { n.Index = r.(Expression) })
+	case *HashLiteral:
+		for i := range n.Pairs {
+			i := i
+			a.applyElement(n, "Pairs", i, n.Pairs[i], func(r Node) { n.Pairs[i] = r.(*HashPair) })
+		}
+	case *HashPair:
+		a.applyExpression(n, "Key", n.Key, func(r Node) { n.Key = r.(Expression) })
+		a.applyExpression(n, "Value", n.Value, func(r Node) { n.Value = r.(Expression) })
+	default:
+		panic(fmt.Sprintf("ast.Apply: unexpected node type %T", n))
+	}
+
+	if a.stopped {
+		return
+	}
+
+	if a.post != nil && !a.post(&a.cursor) {
+		a.stopped = true
+	}
+}
+
+// applyExpression applies the traversal to exp unless it is nil.
+func (a *application) applyExpression(parent Node, name string, exp Expression, set func(Node)) {
+	if exp != nil {
+		a.apply(parent, name, exp, set)
+	}
+}
+
+func (a *application) applyExpressions(parent Node, name string, list []Expression) {
+	for i := range list {
+		i := i
+		a.applyElement(parent, name, i, list[i], func(r Node) { list[i] = r.(Expression) })
+	}
+}
+
+func (a *application) applyElement(parent Node, name string, index int, n Node, set func(Node)) {
+	a.applyAt(Cursor{parent: parent, name: name, node: n, set: set, index: index})
+}
+
+// applyStatements applies the traversal to every statement of list. The list may be
+// modified through the Cursor while it is traversed.
+func (a *application) applyStatements(parent Node, name string, list *[]Statement) {
+	saved := a.iter
+	a.iter.index = 0
+
+	for a.iter.index < len(*list) && !a.stopped {
+		a.iter.step = 1
+
+		iter := &a.iter
+		a.applyAt(Cursor{
+			parent: parent,
+			name:   name,
+			node:   (*list)[a.iter.index],
+			set:    func(r Node) { (*list)[iter.index] = r.(Statement) },
+			list:   list,
+			iter:   iter,
+		})
+
+		a.iter.index += a.iter.step
+	}
+
+	a.iter = saved
+}
+
+// setPos moves n to pos. Only nodes whose position is defined by one of their tokens
+// or by their leftmost child can be moved; a program is left untouched.
+func setPos(n Node, pos token.Pos) {
+	switch n := n.(type) {
+	case nil, *Program:
+		// Nothing to do.
+	case *ExpressionStatement:
+		n.Token.Pos = pos
+
+		if n.Expression != nil {
+			setPos(n.Expression, pos)
+		}
+	case *InfixExpression:
+		setPos(n.Left, pos)
+	case *AssignExpression:
+		setPos(n.Target, pos)
+	case *CallExpression:
+		setPos(n.Function, pos)
+	case *IndexExpression:
+		setPos(n.Left, pos)
+	case *HashPair:
+		setPos(n.Key, pos)
+	case *LetStatement:
+		n.Token.Pos = pos
+	case *ReturnStatement:
+		n.Token.Pos = pos
+	case *WhileStatement:
+		n.Token.Pos = pos
+	case *ForStatement:
+		n.Token.Pos = pos
+	case *BreakStatement:
+		n.Token.Pos = pos
+	case *ContinueStatement:
+		n.Token.Pos = pos
+	case *BlockStatement:
+		n.Token.Pos = pos
+	case *Identifier:
+		n.Token.Pos = pos
+	case *IntegerLiteral:
+		n.Token.Pos = pos
+	case *FloatLiteral:
+		n.Token.Pos = pos
+	case *StringLiteral:
+		n.Token.Pos = pos
+	case *Boolean:
+		n.Token.Pos = pos
+	case *PrefixExpression:
+		n.Token.Pos = pos
+	case *ParenExpression:
+		n.Token.Pos = pos
+	case *IfExpression:
+		n.Token.Pos = pos
+	case *ElseIfBranch:
+		n.Token.Pos = pos
+	case *FunctionLiteral:
+		n.Token.Pos = pos
+	case *ArrayLiteral:
+		n.Token.Pos = pos
+	case *HashLiteral:
+		n.Token.Pos = pos
+	case *BadStatement:
+		n.Token.Pos = pos
+	case *BadExpression:
+		n.Token.Pos = pos
+	default:
+		panic(fmt.Sprintf("ast.setPos: unexpected node type %T", n))
+	}
+}
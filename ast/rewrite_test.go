@@ -0,0 +1,299 @@
+package ast_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mycok/monkey_interpreter/ast"
+	"github.com/mycok/monkey_interpreter/token"
+)
+
+func TestModify(t *testing.T) {
+	one := func(node ast.Node) ast.Node {
+		integer, ok := node.(*ast.IntegerLiteral)
+		if !ok || integer.Value != 1 {
+			return node
+		}
+
+		return &ast.IntegerLiteral{Token: token.Token{Type: token.INT, Literal: "2"}, Value: 2}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1", "2"},
+		{"let x = 1;", "(let x 2)"},
+		{"return 1;", "(return 2)"},
+		{"-1 + 1 * 3", "(+ (- 2) (* 2 3))"},
+		{"a[1] = 1", "(= (index a 2) 2)"},
+		{"if (1) { 1 } elseif (1) { 1 } else { 1 }", "(if 2 (block 2) (elseif 2 (block 2)) (block 2))"},
+		{"fn(x) { 1 }(1)", "(call (fn (x) (block 2)) 2)"},
+		{`[1, {1: 1}]`, "(array 2 (hash (pair 2 2)))"},
+		{"while (1) { 1 } for (x in 1) { 1 }", "(while 2 (block 2)) (for x 2 (block 2))"},
+	}
+
+	for _, tc := range tests {
+		program := parseProgram(t, tc.input)
+
+		modified := ast.Modify(program, one)
+
+		if output := ast.SExpr(modified); output != tc.expected {
+			t.Errorf("%s: expected %s, got %s instead", tc.input, tc.expected, output)
+		}
+	}
+}
+
+func TestModifyIsPostOrder(t *testing.T) {
+	input := "let x = 1 + 2 * (3 - 1);"
+
+	program := parseProgram(t, input)
+
+	fold := func(node ast.Node) ast.Node {
+		infix, ok := node.(*ast.InfixExpression)
+		if !ok {
+			return node
+		}
+
+		left, leftOk := ast.Unparen(infix.Left).(*ast.IntegerLiteral)
+		right, rightOk := ast.Unparen(infix.Right).(*ast.IntegerLiteral)
+		if !leftOk || !rightOk {
+			return node
+		}
+
+		var value int64
+
+		switch infix.Operator {
+		case "+":
+			value = left.Value + right.Value
+		case "-":
+			value = left.Value - right.Value
+		case "*":
+			value = left.Value * right.Value
+		default:
+			return node
+		}
+
+		lit := strconv.FormatInt(value, 10)
+
+		return &ast.IntegerLiteral{Token: token.Token{Type: token.INT, Literal: lit}, Value: value}
+	}
+
+	result := ast.Modify(program, fold)
+
+	let := result.(*ast.Program).Statements[0].(*ast.LetStatement)
+
+	folded, ok := let.Value.(*ast.IntegerLiteral)
+	if !ok {
+		t.Fatalf("let.Value is not *ast.IntegerLiteral. got: %T (%s) instead", let.Value, let.Value)
+	}
+
+	if folded.Value != 5 {
+		t.Errorf("folded.Value is not 5. got: %d instead", folded.Value)
+	}
+
+	// The folded literal takes the place of "1 + 2 * (3 - 1)" which starts at offset 8.
+	if folded.Pos().Offset != 8 || folded.Pos().Line != 1 || folded.Pos().Column != 9 {
+		t.Errorf("folded literal did not inherit the position of the original expression. got: %s (offset %d) instead", folded.Pos(), folded.Pos().Offset)
+	}
+}
+
+func TestApplyDelete(t *testing.T) {
+	input := `debug(1);
+let x = 1;
+debug(2);
+debug(3);
+if (x) { debug(4); x } else { debug(5) }`
+
+	program := parseProgram(t, input)
+
+	var visited []string
+
+	result := ast.Apply(program, func(c *ast.Cursor) bool {
+		stmt, ok := c.Node().(*ast.ExpressionStatement)
+		if !ok {
+			return true
+		}
+
+		visited = append(visited, stmt.String())
+
+		if call, ok := stmt.Expression.(*ast.CallExpression); ok && call.Function.String() == "debug" {
+			c.Delete()
+
+			return false
+		}
+
+		return true
+	}, nil)
+
+	expected := "(let x 1) (if x (block x) (block))"
+	if output := ast.SExpr(result); output != expected {
+		t.Errorf("expected %s, got %s instead", expected, output)
+	}
+
+	if len(visited) != 7 {
+		t.Errorf("expected every expression statement to be visited once. got: %q instead", visited)
+	}
+}
+
+func TestApplyInsertBefore(t *testing.T) {
+	input := `let f = fn(x) {
+	if (x) { return 1; }
+	return 2;
+};`
+
+	program := parseProgram(t, input)
+	trace := parseProgram(t, "trace();").Statements[0]
+
+	var returns int
+
+	ast.Apply(program, nil, func(c *ast.Cursor) bool {
+		if _, ok := c.Node().(*ast.ReturnStatement); ok {
+			returns++
+
+			c.InsertBefore(&ast.ExpressionStatement{Expression: trace.(*ast.ExpressionStatement).Expression})
+		}
+
+		return true
+	})
+
+	expected := "(let f (fn (x) (block (if x (block (call trace) (return 1))) (call trace) (return 2))))"
+	if output := ast.SExpr(program); output != expected {
+		t.Errorf("expected %s, got %s instead", expected, output)
+	}
+
+	if returns != 2 {
+		t.Errorf("expected 2 return statements to be visited. got: %d instead", returns)
+	}
+}
+
+func TestApplyReplace(t *testing.T) {
+	program := parseProgram(t, "let a = b + c;\nd;")
+
+	replacement := parseProgram(t, "x * y").Statements[0].(*ast.ExpressionStatement).Expression
+
+	var (
+		names []string
+		swap  bool
+	)
+
+	result := ast.Apply(program, func(c *ast.Cursor) bool {
+		switch node := c.Node().(type) {
+		case *ast.InfixExpression:
+			if !swap {
+				swap = true
+				c.Replace(replacement)
+			}
+		case *ast.Identifier:
+			names = append(names, c.Name()+":"+node.Value)
+		}
+
+		return true
+	}, nil)
+
+	if output := ast.SExpr(result); output != "(let a (* x y)) d" {
+		t.Errorf("expected %s, got %s instead", "(let a (* x y)) d", output)
+	}
+
+	// The children of the replacement are traversed instead of the original children.
+	if got := strings.Join(names, " "); got != "Name:a Left:x Right:y Expression:d" {
+		t.Errorf("wrong identifiers visited. got: %q instead", got)
+	}
+}
+
+func TestApplyCursor(t *testing.T) {
+	program := parseProgram(t, "a; f(b, c); { }")
+
+	var visited []string
+
+	ast.Apply(program, func(c *ast.Cursor) bool {
+		parent := "nil"
+		if c.Parent() != nil {
+			parent = strings.TrimPrefix(fmt.Sprintf("%T", c.Parent()), "*ast.")
+		}
+
+		visited = append(visited, fmt.Sprintf("%s.%s[%d]", parent, c.Name(), c.Index()))
+
+		return true
+	}, nil)
+
+	expected := []string{
+		"nil.Node[-1]",
+		"Program.Statements[0]",
+		"ExpressionStatement.Expression[-1]",
+		"Program.Statements[1]",
+		"ExpressionStatement.Expression[-1]",
+		"CallExpression.Function[-1]",
+		"CallExpression.Arguments[0]",
+		"CallExpression.Arguments[1]",
+		"Program.Statements[2]",
+		"ExpressionStatement.Expression[-1]",
+	}
+
+	if strings.Join(visited, " ") != strings.Join(expected, " ") {
+		t.Errorf("wrong cursors.\nexpected=%v\ngot=%v", expected, visited)
+	}
+}
+
+func TestApplyStopsWhenPostReturnsFalse(t *testing.T) {
+	program := parseProgram(t, "a; b; c;")
+
+	var visited []string
+
+	ast.Apply(program, nil, func(c *ast.Cursor) bool {
+		if ident, ok := c.Node().(*ast.Identifier); ok {
+			visited = append(visited, ident.Value)
+
+			return ident.Value != "b"
+		}
+
+		return true
+	})
+
+	if strings.Join(visited, " ") != "a b" {
+		t.Errorf("expected traversal to stop after b. got: %q instead", visited)
+	}
+}
+
+func TestApplyDeleteOutsideStatementList(t *testing.T) {
+	program := parseProgram(t, "a + b")
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected Delete on an expression to panic")
+		}
+	}()
+
+	ast.Apply(program, func(c *ast.Cursor) bool {
+		if _, ok := c.Node().(*ast.Identifier); ok {
+			c.Delete()
+		}
+
+		return true
+	}, nil)
+}
+
+func TestModifyNilReplacement(t *testing.T) {
+	program := parseProgram(t, "a + b")
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("expected a nil replacement to panic")
+		}
+
+		if msg, ok := r.(string); !ok || !strings.Contains(msg, "nil node") {
+			t.Errorf("expected a panic about a nil node. got: %v instead", r)
+		}
+	}()
+
+	ast.Modify(program, func(node ast.Node) ast.Node {
+		if _, ok := node.(*ast.Identifier); ok {
+			return nil
+		}
+
+		return node
+	})
+}
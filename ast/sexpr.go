@@ -0,0 +1,128 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+)
+
+// SExpr returns a fully parenthesised, prefix notation representation of node such as
+// (let x (* 5 y)). Unlike String, every operator and construct is explicitly grouped
+// which makes the structure of the tree unambiguous.
+func SExpr(node Node) string {
+	var out bytes.Buffer
+
+	writeSExpr(&out, node)
+
+	return out.String()
+}
+
+func writeSExpr(out *bytes.Buffer, node Node) {
+	switch node := node.(type) {
+	case *Program:
+		for i, s := range node.Statements {
+			if i > 0 {
+				out.WriteString(" ")
+			}
+
+			writeSExpr(out, s)
+		}
+	case *LetStatement:
+		writeList(out, "let", node.Name, node.Value)
+	case *ReturnStatement:
+		if node.ReturnValue == nil {
+			writeList(out, "return")
+		} else {
+			writeList(out, "return", node.ReturnValue)
+		}
+	case *WhileStatement:
+		writeList(out, "while", node.Condition, node.Body)
+	case *ForStatement:
+		writeList(out, "for", node.Variable, node.Iterable, node.Body)
+	case *BreakStatement:
+		writeList(out, "break")
+	case *ContinueStatement:
+		writeList(out, "continue")
+	case *ExpressionStatement:
+		writeSExpr(out, node.Expression)
+	case *BlockStatement:
+		nodes := make([]Node, 0, len(node.Statements))
+		for _, s := range node.Statements {
+			nodes = append(nodes, s)
+		}
+
+		writeList(out, "block", nodes...)
+	case *Identifier, *IntegerLiteral, *FloatLiteral, *Boolean, *StringLiteral:
+		out.WriteString(node.String())
+	case *PrefixExpression:
+		writeList(out, node.Operator, node.Right)
+	case *InfixExpression:
+		writeList(out, node.Operator, node.Left, node.Right)
+	case *ParenExpression:
+		writeSExpr(out, node.Expression)
+	case *AssignExpression:
+		writeList(out, node.Operator, node.Target, node.Value)
+	case *IfExpression:
+		nodes := []Node{node.Condition, node.Consequence}
+		for _, branch := range node.ElseIfs {
+			nodes = append(nodes, branch)
+		}
+
+		if node.Alternative != nil {
+			nodes = append(nodes, node.Alternative)
+		}
+
+		writeList(out, "if", nodes...)
+	case *ElseIfBranch:
+		writeList(out, "elseif", node.Condition, node.Consequence)
+	case *FunctionLiteral:
+		params := make([]string, 0, len(node.Parameters))
+		for _, p := range node.Parameters {
+			params = append(params, p.Value)
+		}
+
+		out.WriteString("(fn (" + strings.Join(params, " ") + ") ")
+		writeSExpr(out, node.Body)
+		out.WriteString(")")
+	case *CallExpression:
+		nodes := []Node{node.Function}
+		for _, a := range node.Arguments {
+			nodes = append(nodes, a)
+		}
+
+		writeList(out, "call", nodes...)
+	case *ArrayLiteral:
+		nodes := make([]Node, 0, len(node.Elements))
+		for _, el := range node.Elements {
+			nodes = append(nodes, el)
+		}
+
+		writeList(out, "array", nodes...)
+	case *IndexExpression:
+		writeList(out, "index", node.Left, node.Index)
+	case *HashLiteral:
+		nodes := make([]Node, 0, len(node.Pairs))
+		for _, pair := range node.Pairs {
+			nodes = append(nodes, pair)
+		}
+
+		writeList(out, "hash", nodes...)
+	case *HashPair:
+		writeList(out, "pair", node.Key, node.Value)
+	case *BadStatement, *BadExpression:
+		writeList(out, "bad")
+	default:
+		// nil nodes produced by parse errors.
+		out.WriteString("nil")
+	}
+}
+
+func writeList(out *bytes.Buffer, head string, nodes ...Node) {
+	out.WriteString("(" + head)
+
+	for _, n := range nodes {
+		out.WriteString(" ")
+		writeSExpr(out, n)
+	}
+
+	out.WriteString(")")
+}
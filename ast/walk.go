@@ -0,0 +1,136 @@
+package ast
+
+import "fmt"
+
+// Visitor is implemented by types passed to Walk. The Visit method is invoked for each
+// node encountered by Walk. If the result visitor w is not nil, Walk visits each of the
+// children of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses an AST in depth-first order. It starts by calling v.Visit(node); node
+// must not be nil. If the visitor w returned by v.Visit(node) is not nil, Walk is
+// invoked recursively with visitor w for each of the non-nil children of node, followed
+// by a call of w.Visit(nil).
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	// Statements
+	case *Program:
+		walkStatements(v, n.Statements)
+	case *LetStatement:
+		Walk(v, n.Name)
+		walkExpression(v, n.Value)
+	case *ReturnStatement:
+		walkExpression(v, n.ReturnValue)
+	case *ExpressionStatement:
+		walkExpression(v, n.Expression)
+	case *BlockStatement:
+		walkStatements(v, n.Statements)
+	case *WhileStatement:
+		walkExpression(v, n.Condition)
+		Walk(v, n.Body)
+	case *ForStatement:
+		Walk(v, n.Variable)
+		walkExpression(v, n.Iterable)
+		Walk(v, n.Body)
+	case *BreakStatement, *ContinueStatement, *BadStatement:
+		// Nothing to do.
+
+	// Expressions
+	case *Identifier, *IntegerLiteral, *FloatLiteral, *StringLiteral, *Boolean, *BadExpression:
+		// Nothing to do.
+	case *PrefixExpression:
+		walkExpression(v, n.Right)
+	case *InfixExpression:
+		walkExpression(v, n.Left)
+		walkExpression(v, n.Right)
+	case *ParenExpression:
+		walkExpression(v, n.Expression)
+	case *AssignExpression:
+		walkExpression(v, n.Target)
+		walkExpression(v, n.Value)
+	case *IfExpression:
+		walkExpression(v, n.Condition)
+		Walk(v, n.Consequence)
+
+		for _, branch := range n.ElseIfs {
+			Walk(v, branch)
+		}
+
+		if n.Alternative != nil {
+			Walk(v, n.Alternative)
+		}
+	case *ElseIfBranch:
+		walkExpression(v, n.Condition)
+		Walk(v, n.Consequence)
+	case *FunctionLiteral:
+		for _, param := range n.Parameters {
+			Walk(v, param)
+		}
+
+		Walk(v, n.Body)
+	case *CallExpression:
+		walkExpression(v, n.Function)
+		walkExpressions(v, n.Arguments)
+	case *ArrayLiteral:
+		walkExpressions(v, n.Elements)
+	case *IndexExpression:
+		walkExpression(v, n.Left)
+		walkExpression(v, n.Index)
+	case *HashLiteral:
+		for _, pair := range n.Pairs {
+			Walk(v, pair)
+		}
+	case *HashPair:
+		walkExpression(v, n.Key)
+		walkExpression(v, n.Value)
+	default:
+		panic(fmt.Sprintf("ast.Walk: unexpected node type %T", n))
+	}
+
+	v.Visit(nil)
+}
+
+func walkStatements(v Visitor, list []Statement) {
+	for _, stmt := range list {
+		if stmt != nil {
+			Walk(v, stmt)
+		}
+	}
+}
+
+func walkExpressions(v Visitor, list []Expression) {
+	for _, exp := range list {
+		walkExpression(v, exp)
+	}
+}
+
+// walkExpression walks exp unless it is nil, which is the case for optional children
+// such as the value of a bare return statement.
+func walkExpression(v Visitor, exp Expression) {
+	if exp != nil {
+		Walk(v, exp)
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+
+	return nil
+}
+
+// Inspect traverses an AST in depth-first order: It starts by calling f(node); node must
+// not be nil. If f returns true, Inspect invokes f recursively for each of the non-nil
+// children of node, followed by a call of f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
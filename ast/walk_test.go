@@ -0,0 +1,293 @@
+package ast_test
+
+import (
+	"fmt"
+	goast "go/ast"
+	goparser "go/parser"
+	gotoken "go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mycok/monkey_interpreter/ast"
+	"github.com/mycok/monkey_interpreter/lexer"
+	"github.com/mycok/monkey_interpreter/parser"
+)
+
+func TestInspect(t *testing.T) {
+	input := `let add = fn(a, b) { return a + b; };
+if (x) { -y } elseif (z) { 1 } else { return; }
+while (i < 3) { i += 1; break; }
+for (k in {"a": [1.5, true]}) { m[k]; continue; }
+add(1, 2);`
+
+	expected := []string{
+		"Program",
+		"  LetStatement",
+		"    Identifier add",
+		"    FunctionLiteral",
+		"      Identifier a",
+		"      Identifier b",
+		"      BlockStatement",
+		"        ReturnStatement",
+		"          InfixExpression +",
+		"            Identifier a",
+		"            Identifier b",
+		"  ExpressionStatement",
+		"    IfExpression",
+		"      Identifier x",
+		"      BlockStatement",
+		"        ExpressionStatement",
+		"          PrefixExpression -",
+		"            Identifier y",
+		"      ElseIfBranch",
+		"        Identifier z",
+		"        BlockStatement",
+		"          ExpressionStatement",
+		"            IntegerLiteral 1",
+		"      BlockStatement",
+		"        ReturnStatement",
+		"  WhileStatement",
+		"    InfixExpression <",
+		"      Identifier i",
+		"      IntegerLiteral 3",
+		"    BlockStatement",
+		"      ExpressionStatement",
+		"        AssignExpression +=",
+		"          Identifier i",
+		"          IntegerLiteral 1",
+		"      BreakStatement",
+		"  ForStatement",
+		"    Identifier k",
+		"    HashLiteral",
+		"      HashPair",
+		`        StringLiteral "a"`,
+		"        ArrayLiteral",
+		"          FloatLiteral 1.5",
+		"          Boolean true",
+		"    BlockStatement",
+		"      ExpressionStatement",
+		"        IndexExpression",
+		"          Identifier m",
+		"          Identifier k",
+		"      ContinueStatement",
+		"  ExpressionStatement",
+		"    CallExpression",
+		"      Identifier add",
+		"      IntegerLiteral 1",
+		"      IntegerLiteral 2",
+	}
+
+	program := parseProgram(t, input)
+
+	var (
+		output []string
+		depth  int
+	)
+
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node == nil {
+			depth--
+
+			return false
+		}
+
+		output = append(output, strings.Repeat("  ", depth)+describe(node))
+		depth++
+
+		return true
+	})
+
+	if depth != 0 {
+		t.Errorf("every visited node expected to be followed by a nil node. got depth: %d instead", depth)
+	}
+
+	if len(output) != len(expected) {
+		t.Fatalf("expected %d nodes. got: %d instead:\n%s", len(expected), len(output), strings.Join(output, "\n"))
+	}
+
+	for i := range expected {
+		if output[i] != expected[i] {
+			t.Errorf("nodes[%d] - expected=%q, got=%q", i, expected[i], output[i])
+		}
+	}
+}
+
+func TestInspectSkipsChildren(t *testing.T) {
+	program := parseProgram(t, "let f = fn(x) { x * 2 }; f(1 + 2);")
+
+	var idents []string
+
+	ast.Inspect(program, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FunctionLiteral:
+			return false
+		case *ast.Identifier:
+			idents = append(idents, node.Value)
+		}
+
+		return true
+	})
+
+	if strings.Join(idents, " ") != "f f" {
+		t.Errorf("idents expected to be %q. got: %q instead", "f f", strings.Join(idents, " "))
+	}
+}
+
+func TestWalkVisitorIsReplaced(t *testing.T) {
+	program := parseProgram(t, "if (a) { b } else { c }; d")
+
+	var visited []string
+	ast.Walk(&depthVisitor{visited: &visited}, program)
+
+	expected := "Program:0 ExpressionStatement:1 IfExpression:2 Identifier:3 BlockStatement:3 " +
+		"ExpressionStatement:4 Identifier:5 BlockStatement:3 ExpressionStatement:4 Identifier:5 " +
+		"ExpressionStatement:1 Identifier:2"
+
+	if got := strings.Join(visited, " "); got != expected {
+		t.Errorf("wrong visit order.\nexpected=%s\ngot=%s", expected, got)
+	}
+}
+
+// TestWalkCoversEveryNodeType makes sure that every type of the ast package that
+// implements ast.Node is handled by ast.Walk and ast.Apply so that new node types can
+// not be added without traversal support.
+func TestWalkCoversEveryNodeType(t *testing.T) {
+	fset := gotoken.NewFileSet()
+
+	names, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("failed to list the ast package: %v", err)
+	}
+
+	files := map[string]*goast.File{}
+
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := goparser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", name, err)
+		}
+
+		files[name] = file
+	}
+
+	nodes := map[string]bool{}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*goast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Name.Name != "TokenLiteral" {
+				continue
+			}
+
+			star, ok := fn.Recv.List[0].Type.(*goast.StarExpr)
+			if !ok {
+				t.Fatalf("TokenLiteral expected to have a pointer receiver. got: %T instead", fn.Recv.List[0].Type)
+			}
+
+			nodes[star.X.(*goast.Ident).Name] = true
+		}
+	}
+
+	if len(nodes) == 0 {
+		t.Fatal("no node types found in ast.go")
+	}
+
+	tests := []struct {
+		file string
+		fn   string
+	}{
+		{"walk.go", "Walk"},
+		{"rewrite.go", "applyAt"},
+		{"rewrite.go", "setPos"},
+	}
+
+	for _, tc := range tests {
+		handled := handledNodeTypes(files[tc.file], tc.fn)
+
+		for name := range nodes {
+			if !handled[name] {
+				t.Errorf("%s does not handle *ast.%s", tc.fn, name)
+			}
+		}
+	}
+}
+
+// handledNodeTypes returns the names of the pointer types listed in the case clauses of
+// the function fn declared in file.
+func handledNodeTypes(file *goast.File, fn string) map[string]bool {
+	handled := map[string]bool{}
+
+	goast.Inspect(file, func(n goast.Node) bool {
+		decl, ok := n.(*goast.FuncDecl)
+		if ok && decl.Name.Name != fn {
+			return false
+		}
+
+		clause, ok := n.(*goast.CaseClause)
+		if !ok {
+			return true
+		}
+
+		for _, exp := range clause.List {
+			if star, ok := exp.(*goast.StarExpr); ok {
+				handled[star.X.(*goast.Ident).Name] = true
+			}
+		}
+
+		return true
+	})
+
+	return handled
+}
+
+// depthVisitor records every visited node together with its depth. A new visitor is
+// returned for the children of every node.
+type depthVisitor struct {
+	depth   int
+	visited *[]string
+}
+
+func (v *depthVisitor) Visit(node ast.Node) ast.Visitor {
+	if node == nil {
+		return nil
+	}
+
+	name := strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast.")
+	*v.visited = append(*v.visited, fmt.Sprintf("%s:%d", name, v.depth))
+
+	return &depthVisitor{depth: v.depth + 1, visited: v.visited}
+}
+
+func describe(node ast.Node) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast.")
+
+	switch node := node.(type) {
+	case *ast.Identifier, *ast.IntegerLiteral, *ast.FloatLiteral, *ast.StringLiteral, *ast.Boolean:
+		return name + " " + node.TokenLiteral()
+	case *ast.PrefixExpression:
+		return name + " " + node.Operator
+	case *ast.InfixExpression:
+		return name + " " + node.Operator
+	case *ast.AssignExpression:
+		return name + " " + node.Operator
+	default:
+		return name
+	}
+}
+
+func parseProgram(t *testing.T, input string) *ast.Program {
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors for %q: %s", input, p.Errors())
+	}
+
+	return program
+}
@@ -0,0 +1,150 @@
+package diagnostic
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mycok/monkey_interpreter/parser"
+)
+
+const (
+	colorReset = "\x1b[0m"
+	colorBold  = "\x1b[1m"
+	colorRed   = "\x1b[31m"
+	colorBlue  = "\x1b[34m"
+	colorCyan  = "\x1b[36m"
+)
+
+// Renderer represents a type that writes parser errors as source annotated diagnostics
+// such as:
+//
+//	error[unexpected token]: expected next token to be =, got: INT instead
+//	 --> script.mk:1:7
+//	  |
+//	1 | let x 5;
+//	  |       ^
+type Renderer struct {
+	out   io.Writer
+	color bool
+}
+
+// New returns an initialized instance of a Renderer that writes to out. Colour output
+// is enabled when out is a terminal and the NO_COLOR environment variable is not set.
+func New(out io.Writer) *Renderer {
+	return &Renderer{out: out, color: isTerminal(out) && os.Getenv("NO_COLOR") == ""}
+}
+
+// SetColor enables or disables colour output.
+func (r *Renderer) SetColor(enabled bool) {
+	r.color = enabled
+}
+
+// Render writes a diagnostic for every error in errs using src as the source text the
+// errors refer to.
+func (r *Renderer) Render(src string, errs parser.ErrorList) {
+	lines := strings.Split(src, "\n")
+
+	for i, err := range errs {
+		if i > 0 {
+			fmt.Fprintln(r.out)
+		}
+
+		r.renderError(lines, err)
+	}
+}
+
+// Render is a convenience function that writes diagnostics for errs to out.
+func Render(out io.Writer, src string, errs parser.ErrorList) {
+	New(out).Render(src, errs)
+}
+
+func (r *Renderer) renderError(lines []string, err *parser.ParseError) {
+	fmt.Fprintf(r.out, "%s: %s\n", r.paint(colorBold+colorRed, "error["+err.Kind.String()+"]"), r.paint(colorBold, err.Msg))
+
+	pos := err.Pos
+	if !pos.IsValid() {
+		if err.Hint != "" {
+			fmt.Fprintf(r.out, "%s %s\n", r.paint(colorCyan, "= hint:"), err.Hint)
+		}
+
+		return
+	}
+
+	lineNum := strconv.Itoa(pos.Line)
+	gutter := strings.Repeat(" ", len(lineNum))
+
+	var line string
+	if pos.Line-1 < len(lines) {
+		line = strings.TrimSuffix(lines[pos.Line-1], "\r")
+	}
+
+	fmt.Fprintf(r.out, "%s%s %s\n", gutter, r.paint(colorBlue, "-->"), pos)
+	fmt.Fprintf(r.out, "%s %s\n", gutter, r.paint(colorBlue, "|"))
+	fmt.Fprintf(r.out, "%s %s\n", r.paint(colorBlue, lineNum), strings.TrimRight(r.paint(colorBlue, "|")+" "+line, " "))
+	fmt.Fprintf(r.out, "%s %s %s%s\n", gutter, r.paint(colorBlue, "|"), padding(line, pos.Column-1), r.paint(colorBold+colorRed, underline(line, err)))
+
+	if err.Hint != "" {
+		fmt.Fprintf(r.out, "%s %s %s\n", gutter, r.paint(colorCyan, "= hint:"), err.Hint)
+	}
+}
+
+func (r *Renderer) paint(color, s string) string {
+	if !r.color {
+		return s
+	}
+
+	return color + s + colorReset
+}
+
+// padding returns whitespace as wide as the first n bytes of line with one space per
+// rune. Tabs are preserved so that the caret lines up with the offending token
+// regardless of the tab width.
+func padding(line string, n int) string {
+	var out strings.Builder
+
+	if n > len(line) {
+		out.WriteString(strings.Repeat(" ", n-len(line)))
+		n = len(line)
+	}
+
+	for _, r := range line[:n] {
+		if r == '\t' {
+			out.WriteByte('\t')
+		} else {
+			out.WriteByte(' ')
+		}
+	}
+
+	return out.String()
+}
+
+// underline returns one caret per rune of the error span within line. Spans that are
+// empty or that cross lines are marked with a single caret.
+func underline(line string, err *parser.ParseError) string {
+	width := 1
+
+	start, end := err.Pos.Column-1, err.End.Column-1
+	if err.End.Line == err.Pos.Line && start < end && end <= len(line) {
+		width = utf8.RuneCountInString(line[start:end])
+	}
+
+	return strings.Repeat("^", width)
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
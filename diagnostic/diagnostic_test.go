@@ -0,0 +1,98 @@
+package diagnostic
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mycok/monkey_interpreter/lexer"
+	"github.com/mycok/monkey_interpreter/parser"
+)
+
+func TestRender(t *testing.T) {
+	input := "let x = 5;\nreturn (x + 1\n"
+
+	l := lexer.NewWithFilename("script.mk", input)
+	p := parser.New(l)
+	p.ParseProgram()
+
+	var out bytes.Buffer
+	Render(&out, input, p.Errors())
+
+	expected := `error[unexpected token]: expected next token to be ), got: EOF instead
+ --> script.mk:3:1
+  |
+3 |
+  | ^
+`
+
+	if out.String() != expected {
+		t.Errorf("wrong diagnostic output. expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestRenderUnderlinesTokenSpan(t *testing.T) {
+	input := "let x = 5;\n\tlet foobar 10;"
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	p.ParseProgram()
+
+	var out bytes.Buffer
+	Render(&out, input, p.Errors()[:1])
+
+	expected := "error[unexpected token]: expected next token to be =, got: INT instead\n" +
+		" --> 2:13\n" +
+		"  |\n" +
+		"2 | \tlet foobar 10;\n" +
+		"  | \t           ^^\n"
+
+	if out.String() != expected {
+		t.Errorf("wrong diagnostic output. expected:\n%q\ngot:\n%q", expected, out.String())
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	input := "let 5;"
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	p.ParseProgram()
+
+	var out bytes.Buffer
+	r := New(&out)
+
+	r.Render(input, p.Errors())
+	if bytes.Contains(out.Bytes(), []byte("\x1b[")) {
+		t.Errorf("expected plain output for a non terminal writer. got: %q", out.String())
+	}
+
+	out.Reset()
+	r.SetColor(true)
+
+	r.Render(input, p.Errors())
+	if !bytes.Contains(out.Bytes(), []byte(colorRed)) {
+		t.Errorf("expected coloured output. got: %q", out.String())
+	}
+}
+
+func TestRenderUnicodeLine(t *testing.T) {
+	input := `return "café😀" 5;`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	p.ParseProgram()
+
+	var out bytes.Buffer
+	Render(&out, input, p.Errors()[:1])
+
+	expected := "error[unexpected token]: expected next token to be ;, got: INT instead\n" +
+		" --> 1:20\n" +
+		"  |\n" +
+		"1 | return \"café😀\" 5;\n" +
+		"  |                ^\n" +
+		"  = hint: did you forget a semicolon?\n"
+
+	if out.String() != expected {
+		t.Errorf("wrong diagnostic output. expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
@@ -0,0 +1,709 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/mycok/monkey_interpreter/lexer"
+	"github.com/mycok/monkey_interpreter/object"
+	"github.com/mycok/monkey_interpreter/parser"
+)
+
+func TestEvalIntegerExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"5", 5},
+		{"10", 10},
+		{"-5", -5},
+		{"+5", 5},
+		{"-10", -10},
+		{"5 + 5 + 5 + 5 - 10", 10},
+		{"2 * 2 * 2 * 2 * 2", 32},
+		{"-50 + 100 + -50", 0},
+		{"5 * 2 + 10", 20},
+		{"5 + 2 * 10", 25},
+		{"20 + 2 * -10", 0},
+		{"50 / 2 * 2 + 10", 60},
+		{"2 * (5 + 10)", 30},
+		{"3 * 3 * 3 + 10", 37},
+		{"3 * (3 * 3) + 10", 37},
+		{"(5 + 10 * 2 + 15 / 3) * 2 + -10", 50},
+		{"10 % 3", 1},
+		{"-7 % 3", -1},
+		{"2 + 10 % 4 * 3", 8},
+		{"12 & 10", 8},
+		{"12 | 10", 14},
+		{"12 ^ 10", 6},
+		{"~0", -1},
+		{"~5", -6},
+		{"1 << 4", 16},
+		{"256 >> 4", 16},
+		{"-16 >> 2", -4},
+		{"1 << 64", 0},
+		{"1 << 63", -9223372036854775808},
+		{"5 >> 64", 0},
+		{"-5 >> 64", -1},
+		{"0xFF & 0x0F | 0x30", 0x3F},
+		{"1 << 2 + 1", 5},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+		testIntegerObject(t, evaluated, tc.expected)
+	}
+}
+
+func TestEvalFloatExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.5", 3.5},
+		{"-2.5", -2.5},
+		{"1.5 + 1.5", 3},
+		{"1 + 0.5", 1.5},
+		{"0.5 * 4", 2},
+		{"7 / 2.0", 3.5},
+		{"1e3 - 1", 999},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+
+		result, ok := evaluated.(*object.Float)
+		if !ok {
+			t.Errorf("object is not *object.Float. got: %T (%+v) instead", evaluated, evaluated)
+			continue
+		}
+
+		if result.Value != tc.expected {
+			t.Errorf("object has wrong value. expected: %g, got: %g instead", tc.expected, result.Value)
+		}
+	}
+
+	testBooleanObject(t, testEval(t, "1.5 < 2"), true)
+	testBooleanObject(t, testEval(t, "2.0 == 2"), true)
+
+	if inspect := testEval(t, "1.5 + 1.5").Inspect(); inspect != "3.0" {
+		t.Errorf("Inspect() is not %q. got: %q instead", "3.0", inspect)
+	}
+}
+
+func TestEvalBooleanExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"true", true},
+		{"false", false},
+		{"1 < 2", true},
+		{"1 > 2", false},
+		{"1 < 1", false},
+		{"1 > 1", false},
+		{"1 == 1", true},
+		{"1 != 1", false},
+		{"1 == 2", false},
+		{"1 != 2", true},
+		{"true == true", true},
+		{"false == false", true},
+		{"true == false", false},
+		{"true != false", true},
+		{"false != true", true},
+		{"(1 < 2) == true", true},
+		{"(1 < 2) == false", false},
+		{"(1 > 2) == true", false},
+		{"(1 > 2) == false", true},
+		{"1 <= 2", true},
+		{"2 <= 2", true},
+		{"3 <= 2", false},
+		{"1 >= 2", false},
+		{"2 >= 2", true},
+		{"1.5 >= 1", true},
+		{"true && true", true},
+		{"true && false", false},
+		{"false || true", true},
+		{"false || false", false},
+		{"1 < 2 && 2 < 3", true},
+		{"1 > 2 || 2 > 3", false},
+		{"0 && false", false},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+		testBooleanObject(t, evaluated, tc.expected)
+	}
+}
+
+func TestBangOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"!true", false},
+		{"!false", true},
+		{"!5", false},
+		{"!!true", true},
+		{"!!false", false},
+		{"!!5", true},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+		testBooleanObject(t, evaluated, tc.expected)
+	}
+}
+
+func TestLogicalOperatorsShortCircuit(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		// The right operands would produce errors if they were evaluated.
+		{"false && undefined", false},
+		{"true || undefined", true},
+		{"false && 1 / 0", false},
+		{"let calls = fn() { 1 / 0 }; true || calls()", true},
+	}
+
+	for _, tc := range tests {
+		testBooleanObject(t, testEval(t, tc.input), tc.expected)
+	}
+
+	evaluated := testEval(t, "true && undefined")
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok || errObj.Message != "identifier not found: undefined" {
+		t.Errorf("expected the right operand to be evaluated. got: %T (%+v) instead", evaluated, evaluated)
+	}
+}
+
+func TestIfElseExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"if (true) { 10 }", 10},
+		{"if (false) { 10 }", nil},
+		{"if (1) { 10 }", 10},
+		{"if (1 < 2) { 10 }", 10},
+		{"if (1 > 2) { 10 }", nil},
+		{"if (1 > 2) { 10 } else { 20 }", 20},
+		{"if (1 < 2) { 10 } else { 20 }", 10},
+		{"if (1 > 2) { 10 } else if (1 < 2) { 15 } else { 20 }", 15},
+		{"if (1 > 2) { 10 } elseif (1 > 3) { 15 } elseif (2 > 1) { 17 } else { 20 }", 17},
+		{"if (1 > 2) { 10 } else if (1 > 3) { 15 } else { 20 }", 20},
+		{"if (true) {}", nil},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+
+		integer, ok := tc.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestReturnStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"return 10;", 10},
+		{"return 10; 9;", 10},
+		{"return 2 * 5; 9;", 10},
+		{"9; return 2 * 5; 9;", 10},
+		{
+			`
+			if (10 > 1) {
+				if (10 > 1) {
+					return 10;
+				}
+
+				return 1;
+			}
+			`,
+			10,
+		},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+		testIntegerObject(t, evaluated, tc.expected)
+	}
+}
+
+func TestReturnInValuePositions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"fn() { let x = if (true) { return 7 }; 9 }()", 7},
+		{"let x = if (true) { return 7 }; x + 1", 7},
+		{"fn() { -if (true) { return 7 } }()", 7},
+		{"fn() { 1 + if (true) { return 7 } else { 2 } }()", 7},
+		{"let f = fn(x) { x }; fn() { f(if (true) { return 7 }) }()", 7},
+		{"fn() { [1, 2][if (true) { return 7 } else { 0 }] }()", 7},
+		{"fn() { let x = 1; x = if (true) { return 7 }; 9 }()", 7},
+		{"fn() { if (if (true) { return 7 } else { false }) { 1 } else { 2 } }()", 7},
+		{"fn() { while (if (true) { return 7 } else { false }) { } 9 }()", 7},
+	}
+
+	for _, tc := range tests {
+		testIntegerObject(t, testEval(t, tc.input), tc.expected)
+	}
+}
+
+func TestBareReturnStatement(t *testing.T) {
+	testNullObject(t, testEval(t, "return; 10;"))
+	testNullObject(t, testEval(t, "fn() { return }()"))
+}
+
+func TestErrorHandling(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"5 + true;", "type mismatch: INTEGER + BOOLEAN"},
+		{"5 + true; 5;", "type mismatch: INTEGER + BOOLEAN"},
+		{"-true", "unknown operator: -BOOLEAN"},
+		{"true + false;", "unknown operator: BOOLEAN + BOOLEAN"},
+		{"5; true + false; 5", "unknown operator: BOOLEAN + BOOLEAN"},
+		{"if (10 > 1) { true + false; }", "unknown operator: BOOLEAN + BOOLEAN"},
+		{
+			`
+			if (10 > 1) {
+				if (10 > 1) {
+					return true + false;
+				}
+
+				return 1;
+			}
+			`,
+			"unknown operator: BOOLEAN + BOOLEAN",
+		},
+		{"foobar", "identifier not found: foobar"},
+		{"10 / 0", "division by zero: 10 / 0"},
+		{"10 % 0", "division by zero: 10 % 0"},
+		{"1 << -1", "negative shift count: 1 << -1"},
+		{"8 >> -2", "negative shift count: 8 >> -2"},
+		{"~true", "unknown operator: ~BOOLEAN"},
+		{"1.5 & 1", "unknown operator: FLOAT & INTEGER"},
+		{"let x = 5; x(1)", "not a function: INTEGER"},
+		{"fn(x) { x }()", "wrong number of arguments: expected 1, got 0"},
+		{`{"name": "Monkey"}[fn(x) { x }];`, "unusable as hash key: FUNCTION"},
+		{`{[1]: 2}`, "unusable as hash key: ARRAY"},
+		{`1[0]`, "index operator not supported: INTEGER[INTEGER]"},
+		{`[1, 2]["a"]`, "index operator not supported: ARRAY[STRING]"},
+		{`[1, foobar]`, "identifier not found: foobar"},
+		{"x = 1", "identifier not found: x"},
+		{"let x = 1; x += true", "type mismatch: INTEGER + BOOLEAN"},
+		{"let x = 1; x /= 0", "division by zero: 1 / 0"},
+		{"let a = [1]; a[1] = 2", "index out of range: 1"},
+		{`let a = [1]; a["x"] = 2`, "index operator not supported: ARRAY[STRING]"},
+		{`let h = {}; h[[1]] = 2`, "unusable as hash key: ARRAY"},
+		{`let h = {}; h["x"] += 2`, "type mismatch: NULL + INTEGER"},
+		{"for (x in 5) { x }", "not iterable: INTEGER"},
+		{"while (y) { 1 }", "identifier not found: y"},
+		{"for (x in [1, 2]) { x + true }", "type mismatch: INTEGER + BOOLEAN"},
+		{"for (x in [1]) { let y = x; } y", "identifier not found: y"},
+		{"for (x in [1]) { } x", "identifier not found: x"},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got: %T(%+v) instead", tc.input, evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != tc.expectedMessage {
+			t.Errorf("wrong error message. expected: %q, got: %q instead", tc.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestEvalBadNodes(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"-@", "invalid expression at 1:2"},
+		{"1 + @", "invalid expression at 1:5"},
+		{"let x = @; x", "invalid expression at 1:9"},
+		{"1;\nlet = 2; 3", "invalid statement at 2:1"},
+	}
+
+	for _, tc := range tests {
+		p := parser.New(lexer.New(tc.input))
+		program := p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Fatalf("expected parser errors for %q. got none", tc.input)
+		}
+
+		evaluated := Eval(program, object.NewEnvironment())
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got: %T(%+v) instead", tc.input, evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != tc.expectedMessage {
+			t.Errorf("wrong error message. expected: %q, got: %q instead", tc.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let a = 5; a;", 5},
+		{"let a = 5 * 5; a;", 25},
+		{"let a = 5; let b = a; b;", 5},
+		{"let a = 5; let b = a; let c = a + b + 5; c;", 15},
+	}
+
+	for _, tc := range tests {
+		testIntegerObject(t, testEval(t, tc.input), tc.expected)
+	}
+}
+
+func TestFunctionApplication(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let identity = fn(x) { x; }; identity(5);", 5},
+		{"let identity = fn(x) { return x; }; identity(5);", 5},
+		{"let double = fn(x) { x * 2; }; double(5);", 10},
+		{"let add = fn(x, y) { x + y; }; add(5, 5);", 10},
+		{"let add = fn(x, y) { x + y; }; add(5 + 5, add(5, 5));", 20},
+		{"fn(x) { x; }(5)", 5},
+	}
+
+	for _, tc := range tests {
+		testIntegerObject(t, testEval(t, tc.input), tc.expected)
+	}
+}
+
+func TestClosures(t *testing.T) {
+	input := `
+	let newAdder = fn(x) {
+		fn(y) { x + y };
+	};
+
+	let addTwo = newAdder(2);
+	addTwo(2);`
+
+	testIntegerObject(t, testEval(t, input), 4)
+}
+
+func TestStringExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"Hello World!"`, "Hello World!"},
+		{`"Hello" + " " + "World!"`, "Hello World!"},
+		{`"tab\there"`, "tab\there"},
+		{`"a" == "a"`, true},
+		{`"a" != "a"`, false},
+		{`"a" == "b"`, false},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+
+		switch expected := tc.expected.(type) {
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("object is not *object.String. got: %T (%+v) instead", evaluated, evaluated)
+				continue
+			}
+
+			if str.Value != expected {
+				t.Errorf("str.Value is not %q. got: %q instead", expected, str.Value)
+			}
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		}
+	}
+
+	evaluated := testEval(t, `"Hello" - "World"`)
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok || errObj.Message != "unknown operator: STRING - STRING" {
+		t.Errorf("expected unknown operator error. got: %T (%+v) instead", evaluated, evaluated)
+	}
+}
+
+func TestArrayLiterals(t *testing.T) {
+	evaluated := testEval(t, "[1, 2 * 2, 3 + 3]")
+
+	result, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not *object.Array. got: %T (%+v) instead", evaluated, evaluated)
+	}
+
+	if len(result.Elements) != 3 {
+		t.Fatalf("array has wrong number of elements. expected: 3, got: %d instead", len(result.Elements))
+	}
+
+	testIntegerObject(t, result.Elements[0], 1)
+	testIntegerObject(t, result.Elements[1], 4)
+	testIntegerObject(t, result.Elements[2], 6)
+}
+
+func TestArrayIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"[1, 2, 3][0]", 1},
+		{"[1, 2, 3][1]", 2},
+		{"[1, 2, 3][2]", 3},
+		{"let i = 0; [1][i];", 1},
+		{"[1, 2, 3][1 + 1];", 3},
+		{"let myArray = [1, 2, 3]; myArray[2];", 3},
+		{"let myArray = [1, 2, 3]; myArray[0] + myArray[1] + myArray[2];", 6},
+		{"let myArray = [1, 2, 3]; let i = myArray[0]; myArray[i]", 2},
+		{"[[1, 2], [3, 4]][1][0]", 3},
+		{"[1, 2, 3][3]", nil},
+		{"[1, 2, 3][-1]", nil},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+
+		if integer, ok := tc.expected.(int); ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestHashLiterals(t *testing.T) {
+	input := `let two = "two";
+	{
+		"one": 10 - 9,
+		two: 1 + 1,
+		"thr" + "ee": 6 / 2,
+		4: 4,
+		true: 5,
+		false: 6,
+		"one": 7,
+	}`
+
+	evaluated := testEval(t, input)
+
+	result, ok := evaluated.(*object.Hash)
+	if !ok {
+		t.Fatalf("object is not *object.Hash. got: %T (%+v) instead", evaluated, evaluated)
+	}
+
+	expected := map[object.HashKey]int64{
+		(&object.String{Value: "one"}).HashKey():   7,
+		(&object.String{Value: "two"}).HashKey():   2,
+		(&object.String{Value: "three"}).HashKey(): 3,
+		(&object.Integer{Value: 4}).HashKey():      4,
+		TRUE.HashKey():                             5,
+		FALSE.HashKey():                            6,
+	}
+
+	if len(result.Pairs) != len(expected) {
+		t.Fatalf("hash has wrong number of pairs. expected: %d, got: %d instead", len(expected), len(result.Pairs))
+	}
+
+	for expectedKey, expectedValue := range expected {
+		pair, ok := result.Pairs[expectedKey]
+		if !ok {
+			t.Errorf("no pair for the given key in Pairs")
+			continue
+		}
+
+		testIntegerObject(t, pair.Value, expectedValue)
+	}
+
+	inspect := `{one: 7, two: 2, three: 3, 4: 4, true: 5, false: 6}`
+	if result.Inspect() != inspect {
+		t.Errorf("result.Inspect() is not %q. got: %q instead", inspect, result.Inspect())
+	}
+}
+
+func TestHashIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`{"foo": 5}["foo"]`, 5},
+		{`{"foo": 5}["bar"]`, nil},
+		{`let key = "foo"; {"foo": 5}[key]`, 5},
+		{`{}["foo"]`, nil},
+		{`{5: 5}[5]`, 5},
+		{`{true: 5}[true]`, 5},
+		{`{false: 5}[false]`, 5},
+		{`{"a": {"b": [1, 2]}}["a"]["b"][1]`, 2},
+	}
+
+	for _, tc := range tests {
+		evaluated := testEval(t, tc.input)
+
+		if integer, ok := tc.expected.(int); ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let x = 1; x = 2; x;", 2},
+		{"let x = 1; x = 2;", 2},
+		{"let x = 1; x += 2; x;", 3},
+		{"let x = 10; x -= 2; x;", 8},
+		{"let x = 3; x *= 4; x;", 12},
+		{"let x = 12; x /= 4; x;", 3},
+		{"let a = 1; let b = 2; a = b = 5; a + b;", 10},
+		{"let x = 1; let f = fn() { x = x + 1; }; f(); f(); x;", 3},
+		{"let x = 1; let f = fn(x) { x = 10; }; f(2); x;", 1},
+		{"let counter = fn() { let n = 0; fn() { n += 1 } }(); counter(); counter();", 2},
+		{"let a = [1, 2, 3]; a[1] = 20; a[1];", 20},
+		{"let a = [1, 2, 3]; a[2] *= 5; a[2];", 15},
+		{`let h = {"a": 1}; h["b"] = 2; h["a"] + h["b"];`, 3},
+		{`let h = {"a": [1, 2]}; h["a"][0] += 4; h["a"][0];`, 5},
+		{"let x = 1; (x) = 7; x;", 7},
+		{"let a = [1, 2]; (a[0]) += 3; a[0];", 4},
+	}
+
+	for _, tc := range tests {
+		testIntegerObject(t, testEval(t, tc.input), tc.expected)
+	}
+}
+
+func TestLoopStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let i = 0; while (i < 10) { i += 1; } i;", 10},
+		{"let i = 0; while (false) { i += 1; } i;", 0},
+		{"let i = 0; while (true) { i += 1; if (i == 5) { break; } } i;", 5},
+		{"let i = 0; let sum = 0; while (i < 5) { i += 1; if (i % 2 == 0) { continue } sum += i; } sum;", 9},
+		{"let sum = 0; for (x in [1, 2, 3, 4]) { sum += x; } sum;", 10},
+		{"let sum = 0; for (x in [1, 2, 3, 4]) { if (x == 3) { break } sum += x; } sum;", 3},
+		{"let sum = 0; for (x in [1, 2, 3, 4]) { if (x == 3) { continue } sum += x; } sum;", 7},
+		{`let h = {"a": 1, "b": 2}; let sum = 0; for (k in h) { sum += h[k]; } sum;`, 3},
+		{`let n = 0; for (c in "héllo") { n += 1; } n;`, 5},
+		{"let n = 0; for (x in [1, 2]) { for (y in [1, 2, 3]) { if (y == 2) { break } n += 1; } } n;", 2},
+		{"let f = fn() { for (x in [1, 2, 3]) { if (x == 2) { return x * 10; } } 0; }; f();", 20},
+		{"let f = fn() { while (true) { return 7; } }; f();", 7},
+		{"let x = 5; for (x in [1, 2]) { } x;", 5},
+		{"let n = 0; for (i in [1, 2, 3]) { let n = i; } n;", 0},
+	}
+
+	for _, tc := range tests {
+		testIntegerObject(t, testEval(t, tc.input), tc.expected)
+	}
+}
+
+func TestLoopSignalsInValuePositions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let i = 0; while (true) { i += 1; let v = if (i == 3) { break }; } i;", 3},
+		{"let i = 0; let v = 0; while (true) { i += 1; v = if (i == 3) { break } else { i }; } v;", 2},
+		{"let n = 0; for (x in [1, 2, 3]) { let v = if (x == 2) { continue } else { x }; n += v; } n;", 4},
+		{"let f = fn(x) { x }; let n = 0; for (x in [1, 2, 3]) { n += f(if (x == 2) { continue } else { x }); } n;", 4},
+		{"let n = 0; for (x in [1, 2, 3]) { n = n + [if (x == 3) { break } else { x }][0]; } n;", 3},
+		{"let n = 0; for (x in [1, 2, 3]) { n += 1 + if (x == 2) { break } else { 0 }; } n;", 1},
+	}
+
+	for _, tc := range tests {
+		testIntegerObject(t, testEval(t, tc.input), tc.expected)
+	}
+}
+
+func TestLoopClosuresCaptureIteration(t *testing.T) {
+	input := `
+	let fns = {};
+	for (x in [1, 2, 3]) { fns[x] = fn() { x * 10 }; }
+	fns[1]() + fns[3]();
+	`
+
+	testIntegerObject(t, testEval(t, input), 40)
+}
+
+func testEval(t *testing.T, input string) object.Object {
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors for %q: %s", input, p.Errors())
+	}
+
+	env := object.NewEnvironment()
+
+	return Eval(program, env)
+}
+
+func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
+	result, ok := obj.(*object.Integer)
+	if !ok {
+		t.Errorf("object is not *object.Integer. got: %T (%+v) instead", obj, obj)
+
+		return false
+	}
+
+	if result.Value != expected {
+		t.Errorf("object has wrong value. expected: %d, got: %d instead", expected, result.Value)
+
+		return false
+	}
+
+	return true
+}
+
+func testBooleanObject(t *testing.T, obj object.Object, expected bool) bool {
+	result, ok := obj.(*object.Boolean)
+	if !ok {
+		t.Errorf("object is not *object.Boolean. got: %T (%+v) instead", obj, obj)
+
+		return false
+	}
+
+	if result.Value != expected {
+		t.Errorf("object has wrong value. expected: %t, got: %t instead", expected, result.Value)
+
+		return false
+	}
+
+	return true
+}
+
+func testNullObject(t *testing.T, obj object.Object) bool {
+	if obj != NULL {
+		t.Errorf("object is not NULL. got: %T (%+v) instead", obj, obj)
+
+		return false
+	}
+
+	return true
+}
@@ -1,3 +1,3 @@
 module github.com/mycok/monkey_interpreter
 
-go 1.17
+go 1.18
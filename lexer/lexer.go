@@ -1,65 +1,208 @@
 package lexer
 
 import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/mycok/monkey_interpreter/token"
 )
 
 // Lexer represents a Lexer object / type.
 type Lexer struct {
 	input        string
+	filename     string
 	position     int  // current position in input (points to the current char / position of that char in the input)
 	readPosition int  // current reading's position in input (after current char)
-	char         byte // current char under examination
+	char         rune // current char under examination
+	line         int  // line of the current char, starting at 1
+	lineStart    int  // byte offset of the first char of the current line
+	runeColumn   int  // column of the current char in runes, starting at 1
+	errors       []Error
+	comments     bool // emit comments as COMMENT tokens instead of skipping them
+
+	// Fields used when the input is streamed from an io.Reader. input then only holds a
+	// window of the source that starts at byte offset base.
+	reader     io.Reader
+	chunk      []byte
+	base       int
+	bufferSize int
+	readErr    *Error // Error that stopped reading from reader, nil otherwise.
+}
+
+// Option represents a function that configures a Lexer.
+type Option func(*Lexer)
+
+// WithComments configures the lexer to emit comments as COMMENT tokens instead of
+// discarding them. This allows tools such as formatters to retain comments.
+func WithComments() Option {
+	return func(l *Lexer) {
+		l.comments = true
+	}
 }
 
+// Error represents a lexical error. Every ILLEGAL token produced by the lexer has a
+// matching Error that describes why the token is illegal.
+type Error struct {
+	Pos token.Pos
+	Msg string
+}
+
+// Error returns the error message prefixed with the error position.
+func (e Error) Error() string { return e.Pos.String() + ": " + e.Msg }
+
 // New returns an initialized instance of a Lexer.
-func New(input string) *Lexer {
-	l := &Lexer{input: input}
+func New(input string, opts ...Option) *Lexer {
+	return NewWithFilename("", input, opts...)
+}
+
+// NewWithFilename returns an initialized instance of a Lexer whose token positions
+// report the provided filename.
+func NewWithFilename(filename, input string, opts ...Option) *Lexer {
+	l := &Lexer{input: input, filename: filename, line: 1}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
 	// Set all the remaining lexer fields by calling l.readChar.
 	l.readChar()
 
 	return l
 }
 
+// readChar decodes the next UTF-8 encoded char of the input. An invalid encoding is
+// decoded as utf8.RuneError and consumes a single byte.
 func (l *Lexer) readChar() {
+	// Make sure that the next char is fully buffered when streaming from a reader.
+	l.ensureRune(l.readPosition)
+
+	// Advance the line and column based on the char we are moving past.
+	if l.char == '\n' {
+		l.line++
+		l.lineStart = l.readPosition
+		l.runeColumn = 1
+	} else {
+		l.runeColumn++
+	}
+
+	l.position = l.readPosition
+
 	if l.readPosition >= len(l.input) {
 		// Out of range scenario.
 		l.char = 0
-	} else {
-		l.char = l.input[l.readPosition]
+		l.readPosition = len(l.input)
+
+		return
 	}
 
-	l.position = l.readPosition
-	l.readPosition++
+	char, width := utf8.DecodeRuneInString(l.input[l.readPosition:])
+
+	l.char = char
+	l.readPosition += width
+}
+
+// pos returns the source position of the current char.
+func (l *Lexer) pos() token.Pos {
+	return token.Pos{
+		Filename:   l.filename,
+		Offset:     l.base + l.position,
+		Line:       l.line,
+		Column:     l.position - l.lineStart + 1,
+		RuneColumn: l.runeColumn,
+	}
+}
+
+// isInvalidEncoding reports whether the current char is an invalid UTF-8 encoding as
+// opposed to a valid encoding of utf8.RuneError.
+func (l *Lexer) isInvalidEncoding() bool {
+	return l.char == utf8.RuneError && l.readPosition-l.position == 1
+}
+
+// atEOF reports whether the lexer has consumed the entire input. It is used instead of
+// comparing l.char with 0 so that a NUL char within the input is not mistaken for the end
+// of the input.
+func (l *Lexer) atEOF() bool {
+	return l.position >= len(l.input)
+}
+
+// Errors returns the lexical errors encountered so far.
+func (l *Lexer) Errors() []Error {
+	return l.errors
 }
 
 // NextToken returns the current token based on the value of l.char.
 func (l *Lexer) NextToken() token.Token {
-	var tok token.Token
+	for {
+		l.skipWhiteSpace()
+		l.compact()
+
+		pos := l.pos()
 
-	l.skipWhiteSpace()
+		if l.char == '/' && (l.peekChar() == '/' || l.peekChar() == '*') {
+			tok := l.readComment()
+			tok.Pos = pos
+
+			if tok.Type == token.COMMENT && !l.comments {
+				continue
+			}
+
+			return tok
+		}
+
+		tok := l.nextToken()
+		tok.Pos = pos
+
+		return tok
+	}
+}
+
+func (l *Lexer) nextToken() token.Token {
+	var tok token.Token
 
 	switch l.char {
 	case '+':
-		tok = newToken(token.PLUS, l.char)
+		tok = l.makeTwoCharToken('=', token.PLUS_ASSIGN, token.PLUS)
 	case '-':
-		tok = newToken(token.MINUS, l.char)
+		tok = l.makeTwoCharToken('=', token.MINUS_ASSIGN, token.MINUS)
 	case '!':
 		tok = l.makeTwoCharToken('=', token.NOTEQ, token.BANG)
 	case '/':
-		tok = newToken(token.SLASH, l.char)
+		tok = l.makeTwoCharToken('=', token.SLASH_ASSIGN, token.SLASH)
 	case '*':
-		tok = newToken(token.ASTERISK, l.char)
+		tok = l.makeTwoCharToken('=', token.ASTERISK_ASSIGN, token.ASTERISK)
+	case '%':
+		tok = newToken(token.MODULO, l.char)
 	case '<':
-		tok = newToken(token.LT, l.char)
+		if l.peekChar() == '<' {
+			tok = l.makeTwoCharToken('<', token.SHL, token.LT)
+		} else {
+			tok = l.makeTwoCharToken('=', token.LTE, token.LT)
+		}
 	case '>':
-		tok = newToken(token.GT, l.char)
+		if l.peekChar() == '>' {
+			tok = l.makeTwoCharToken('>', token.SHR, token.GT)
+		} else {
+			tok = l.makeTwoCharToken('=', token.GTE, token.GT)
+		}
+	case '&':
+		tok = l.makeTwoCharToken('&', token.AND, token.BITAND)
+	case '|':
+		tok = l.makeTwoCharToken('|', token.OR, token.BITOR)
+	case '^':
+		tok = newToken(token.BITXOR, l.char)
+	case '~':
+		tok = newToken(token.BITNOT, l.char)
 	case '=':
 		tok = l.makeTwoCharToken('=', token.EQ, token.ASSIGN)
 	case ',':
 		tok = newToken(token.COMMA, l.char)
 	case ';':
 		tok = newToken(token.SEMICOLON, l.char)
+	case ':':
+		tok = newToken(token.COLON, l.char)
 	case '(':
 		tok = newToken(token.LPAREN, l.char)
 	case ')':
@@ -68,20 +211,32 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.LBRACE, l.char)
 	case '}':
 		tok = newToken(token.RBRACE, l.char)
+	case '[':
+		tok = newToken(token.LBRACKET, l.char)
+	case ']':
+		tok = newToken(token.RBRACKET, l.char)
+	case '"':
+		return l.readString()
 	case 0:
+		if !l.atEOF() {
+			// A NUL char within the input must not be mistaken for the end of the input.
+			tok = newToken(token.ILLEGAL, l.char)
+			l.addError(l.pos(), "illegal character NUL")
+
+			break
+		}
+
 		tok.Literal = ""
 		tok.Type = token.EOF
 	default:
 		if isLetter(l.char) {
+			position := l.position
 			literal := l.readIdentifiersAndNumbers(isLetter)
 
-			// TODO: figure out a way to get rid of these if/else statements for easy readability.
-			if literal == "else" && l.peekChar() == 'i' {
-				l.readChar()
-				nextLiteral := l.readIdentifiersAndNumbers(isLetter)
-
-				tok.Literal = literal + nextLiteral
-				tok.Type = token.LookupIndentifier(tok.Literal)
+			// "else if" is lexed as a single ELSEIF token the same way as the "elseif" keyword.
+			if literal == "else" && l.skipToElseIf() {
+				tok.Literal = l.input[position:l.position]
+				tok.Type = token.ELSEIF
 
 				return tok
 			}
@@ -91,13 +246,13 @@ func (l *Lexer) NextToken() token.Token {
 
 			return tok
 		} else if isDigit(l.char) {
-			tok.Literal = l.readIdentifiersAndNumbers(isDigit)
-			tok.Type = token.INT
-
-			return tok
-
+			return l.readNumber()
+		} else if l.isInvalidEncoding() {
+			tok = token.Token{Type: token.ILLEGAL, Literal: l.input[l.position:l.readPosition]}
+			l.addError(l.pos(), fmt.Sprintf("invalid UTF-8 encoding %q", tok.Literal))
 		} else {
 			tok = newToken(token.ILLEGAL, l.char)
+			l.addError(l.pos(), fmt.Sprintf("illegal character %q", l.char))
 		}
 	}
 
@@ -106,7 +261,235 @@ func (l *Lexer) NextToken() token.Token {
 	return tok
 }
 
-func (l *Lexer) readIdentifiersAndNumbers(fn func(ch byte) bool) string {
+// readString reads a double quoted string literal and returns it as a STRING token whose
+// literal is the quoted source text. Use Unquote to obtain the value of the string.
+// Strings may not span multiple lines.
+func (l *Lexer) readString() token.Token {
+	pos := l.pos()
+	position := l.position
+
+	for {
+		l.readChar()
+
+		if l.char == '\\' && l.peekChar() != '\n' && l.readPosition < len(l.input) {
+			// Skip the escaped char so that an escaped quote does not end the string.
+			l.readChar()
+			continue
+		}
+
+		if l.char == '"' || l.char == '\n' || l.atEOF() {
+			break
+		}
+	}
+
+	if l.char != '"' {
+		literal := l.input[position:l.position]
+		l.addError(pos, "unterminated string literal")
+
+		return token.Token{Type: token.ILLEGAL, Literal: literal}
+	}
+
+	l.readChar()
+	literal := l.input[position:l.position]
+
+	if !utf8.ValidString(literal) {
+		l.addError(pos, "invalid UTF-8 encoding in string literal")
+
+		return token.Token{Type: token.ILLEGAL, Literal: literal}
+	}
+
+	if _, err := Unquote(literal); err != nil {
+		l.addError(pos, err.Error())
+
+		return token.Token{Type: token.ILLEGAL, Literal: literal}
+	}
+
+	return token.Token{Type: token.STRING, Literal: literal}
+}
+
+// readComment reads a "//" line comment or a "/* */" block comment and returns it as a
+// COMMENT token whose literal is the comment source text. Block comments may be nested.
+func (l *Lexer) readComment() token.Token {
+	pos := l.pos()
+	position := l.position
+
+	if l.peekChar() == '/' {
+		for l.char != '\n' && !l.atEOF() {
+			l.readChar()
+		}
+
+		return token.Token{Type: token.COMMENT, Literal: l.input[position:l.position]}
+	}
+
+	// Move past the opening "/*".
+	l.readChar()
+	l.readChar()
+
+	depth := 1
+
+	for depth > 0 {
+		switch {
+		case l.atEOF():
+			l.addError(pos, "unterminated block comment")
+
+			return token.Token{Type: token.ILLEGAL, Literal: l.input[position:l.position]}
+		case l.char == '/' && l.peekChar() == '*':
+			depth++
+			l.readChar()
+		case l.char == '*' && l.peekChar() == '/':
+			depth--
+			l.readChar()
+		}
+
+		l.readChar()
+	}
+
+	return token.Token{Type: token.COMMENT, Literal: l.input[position:l.position]}
+}
+
+func (l *Lexer) addError(pos token.Pos, msg string) {
+	l.errors = append(l.errors, Error{Pos: pos, Msg: msg})
+}
+
+// readNumber reads an integer or a floating point number. A number is a float if it
+// contains a fractional part such as 3.14, an exponent such as 1e9 or both such as 1.5e-3.
+// Integers may also be written in hexadecimal (0xFF), octal (0o755) or binary (0b1010)
+// notation and the digits of any number may be separated by underscores (1_000_000).
+// Decimal integers other than 0 must not start with a zero. The literal of the returned token is always the original spelling of the number.
+func (l *Lexer) readNumber() token.Token {
+	if l.char == '0' && basePrefixes[l.peekChar()] != "" {
+		return l.readPrefixedInteger()
+	}
+
+	pos := l.pos()
+	position := l.position
+	tokenType := token.TokenType(token.INT)
+
+	l.readIdentifiersAndNumbers(isDecimalDigit)
+
+	// A fractional part requires at least one digit after the dot.
+	if l.char == '.' && isDigit(l.peekChar()) {
+		tokenType = token.FLOAT
+
+		l.readChar()
+		l.readIdentifiersAndNumbers(isDecimalDigit)
+	}
+
+	// An exponent requires at least one digit after the optional sign.
+	if l.char == 'e' || l.char == 'E' {
+		next := l.peekChar()
+		if isDigit(next) || (next == '+' || next == '-') && isDigit(l.peekCharAt(2)) {
+			tokenType = token.FLOAT
+
+			l.readChar()
+			if l.char == '+' || l.char == '-' {
+				l.readChar()
+			}
+
+			l.readIdentifiersAndNumbers(isDecimalDigit)
+		}
+	}
+
+	literal := l.input[position:l.position]
+
+	if !hasValidSeparators(literal, isDigit) {
+		l.addError(pos, fmt.Sprintf("invalid number literal %s: '_' must separate successive digits", literal))
+
+		return token.Token{Type: token.ILLEGAL, Literal: literal}
+	}
+
+	// Leading zeros are rejected instead of denoting an octal literal as they do in C,
+	// so that 010 is not silently read as 8.
+	if tokenType == token.INT && len(literal) > 1 && literal[0] == '0' {
+		l.addError(pos, fmt.Sprintf("invalid number literal %s: leading zeros are not allowed, use 0o for octal", literal))
+
+		return token.Token{Type: token.ILLEGAL, Literal: literal}
+	}
+
+	return token.Token{Type: tokenType, Literal: literal}
+}
+
+// basePrefixes maps the second char of an integer base prefix such as the "x" of 0x to
+// the name of the base.
+var basePrefixes = map[rune]string{
+	'x': "hexadecimal",
+	'X': "hexadecimal",
+	'o': "octal",
+	'O': "octal",
+	'b': "binary",
+	'B': "binary",
+}
+
+// readPrefixedInteger reads a hexadecimal, octal or binary integer literal.
+func (l *Lexer) readPrefixedInteger() token.Token {
+	pos := l.pos()
+	position := l.position
+	base := basePrefixes[l.peekChar()]
+
+	isBaseDigit := isHexDigit
+	switch base {
+	case "octal":
+		isBaseDigit = isOctalDigit
+	case "binary":
+		isBaseDigit = isBinaryDigit
+	}
+
+	// Move past the base prefix and read every char that could belong to the literal so
+	// that invalid digits such as the 2 in 0b102 are reported as part of the number.
+	l.readChar()
+	l.readChar()
+	l.readIdentifiersAndNumbers(func(char rune) bool { return isLetter(char) || isDigit(char) })
+
+	literal := l.input[position:l.position]
+	digits := strings.ReplaceAll(literal[2:], "_", "")
+
+	invalid := strings.IndexFunc(digits, func(r rune) bool { return !isBaseDigit(r) })
+
+	var msg string
+
+	switch {
+	case len(digits) == 0:
+		msg = fmt.Sprintf("invalid number literal %s: %s literal has no digits", literal, base)
+	case invalid >= 0:
+		r, _ := utf8.DecodeRuneInString(digits[invalid:])
+		msg = fmt.Sprintf("invalid number literal %s: invalid digit %q in %s literal", literal, r, base)
+	case !hasValidSeparators(literal[1:], isBaseDigit):
+		// The prefix letter is kept so that a separator directly after the prefix is valid.
+		msg = fmt.Sprintf("invalid number literal %s: '_' must separate successive digits", literal)
+	}
+
+	if msg != "" {
+		l.addError(pos, msg)
+
+		return token.Token{Type: token.ILLEGAL, Literal: literal}
+	}
+
+	return token.Token{Type: token.INT, Literal: literal}
+}
+
+// hasValidSeparators reports whether every underscore in literal is placed between two
+// chars for which isDigitFn returns true. The first char of literal is treated as a digit
+// so that a separator directly after a base prefix is accepted. Number literals only
+// contain ASCII chars which makes it safe to inspect literal byte by byte.
+func hasValidSeparators(literal string, isDigitFn func(rune) bool) bool {
+	for i := 0; i < len(literal); i++ {
+		if literal[i] != '_' {
+			continue
+		}
+
+		if i == 0 || i+1 >= len(literal) || !isDigitFn(rune(literal[i+1])) {
+			return false
+		}
+
+		if i > 1 && !isDigitFn(rune(literal[i-1])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l *Lexer) readIdentifiersAndNumbers(fn func(ch rune) bool) string {
 	position := l.position
 
 	for fn(l.char) {
@@ -117,21 +500,63 @@ func (l *Lexer) readIdentifiersAndNumbers(fn func(ch byte) bool) string {
 	return l.input[position:l.position]
 }
 
+// skipToElseIf checks whether the "else" keyword that was just read is followed by
+// spaces or tabs and an "if" keyword. If it is, the lexer is advanced past the "if"
+// keyword and true is returned. Otherwise the lexer is left untouched.
+func (l *Lexer) skipToElseIf() bool {
+	i := l.position
+	for l.ensure(i+1) && (l.input[i] == ' ' || l.input[i] == '\t') {
+		i++
+	}
+
+	l.ensureRune(i + len("if"))
+
+	if i == l.position || !strings.HasPrefix(l.input[i:], "if") {
+		return false
+	}
+
+	// Make sure that "if" is a keyword and not the start of an identifier such as "iffy".
+	if next, _ := utf8.DecodeRuneInString(l.input[i+len("if"):]); isLetter(next) || isDigit(next) {
+		return false
+	}
+
+	for l.position < i+len("if") {
+		l.readChar()
+	}
+
+	return true
+}
+
 func (l *Lexer) skipWhiteSpace() {
 	for l.char == ' ' || l.char == '\t' || l.char == '\n' || l.char == '\r' {
 		l.readChar()
 	}
 }
 
-func (l *Lexer) peekChar() byte {
-	if l.readPosition >= len(l.input) {
+func (l *Lexer) peekChar() rune {
+	return l.peekCharAt(1)
+}
+
+// peekCharAt returns the char n positions ahead of the current char without advancing
+// the lexer. peekCharAt(1) is equivalent to peekChar().
+func (l *Lexer) peekCharAt(n int) rune {
+	position := l.readPosition
+
+	for ; n > 1 && l.ensureRune(position); n-- {
+		_, width := utf8.DecodeRuneInString(l.input[position:])
+		position += width
+	}
+
+	if !l.ensureRune(position) {
 		return 0
 	}
 
-	return l.input[l.readPosition]
+	char, _ := utf8.DecodeRuneInString(l.input[position:])
+
+	return char
 }
 
-func (l *Lexer) makeTwoCharToken(char byte, twoCharType token.TokenType, defaultType token.TokenType) token.Token {
+func (l *Lexer) makeTwoCharToken(char rune, twoCharType token.TokenType, defaultType token.TokenType) token.Token {
 	if l.peekChar() == char {
 		ch := l.char
 		l.readChar()
@@ -141,14 +566,33 @@ func (l *Lexer) makeTwoCharToken(char byte, twoCharType token.TokenType, default
 	return newToken(defaultType, l.char)
 }
 
-func newToken(tokenType token.TokenType, char byte) token.Token {
+func newToken(tokenType token.TokenType, char rune) token.Token {
 	return token.Token{Type: tokenType, Literal: string(char)}
 }
 
-func isLetter(char byte) bool {
-	return 'a' <= char && char <= 'z' || 'A' <= char && char <= 'Z' || char == '_'
+// isLetter reports whether char can be part of an identifier. Any unicode letter is
+// accepted so that identifiers such as café are valid.
+func isLetter(char rune) bool {
+	return 'a' <= char && char <= 'z' || 'A' <= char && char <= 'Z' || char == '_' ||
+		char >= utf8.RuneSelf && unicode.IsLetter(char)
 }
 
-func isDigit(char byte) bool {
+func isDigit(char rune) bool {
 	return '0' <= char && char <= '9'
 }
+
+func isDecimalDigit(char rune) bool {
+	return isDigit(char) || char == '_'
+}
+
+func isHexDigit(char rune) bool {
+	return isDigit(char) || 'a' <= char && char <= 'f' || 'A' <= char && char <= 'F'
+}
+
+func isOctalDigit(char rune) bool {
+	return '0' <= char && char <= '7'
+}
+
+func isBinaryDigit(char rune) bool {
+	return char == '0' || char == '1'
+}
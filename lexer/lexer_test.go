@@ -15,7 +15,7 @@ let add = fn(x, y) {
 };
 		
 let result = add(five, ten);
-!-/*5;
+!-/ *5;
 5 < 10 > 5;
 if (5 < 10) {
 	return true;
@@ -129,3 +129,769 @@ if (5 < 10) {
 		}
 	}
 }
+
+func TestNextTokenPositions(t *testing.T) {
+	input := "let x = 5;\n  x + 10;\n"
+
+	tests := []struct {
+		expectedType   token.TokenType
+		expectedOffset int
+		expectedLine   int
+		expectedColumn int
+	}{
+		{token.LET, 0, 1, 1},
+		{token.IDENT, 4, 1, 5},
+		{token.ASSIGN, 6, 1, 7},
+		{token.INT, 8, 1, 9},
+		{token.SEMICOLON, 9, 1, 10},
+		{token.IDENT, 13, 2, 3},
+		{token.PLUS, 15, 2, 5},
+		{token.INT, 17, 2, 7},
+		{token.SEMICOLON, 19, 2, 9},
+		{token.EOF, 21, 3, 1},
+	}
+
+	l := NewWithFilename("test.mk", input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Pos.Offset != tc.expectedOffset {
+			t.Errorf("tests[%d] - wrong offset. expected=%d, got=%d", i, tc.expectedOffset, tok.Pos.Offset)
+		}
+
+		if tok.Pos.Line != tc.expectedLine {
+			t.Errorf("tests[%d] - wrong line. expected=%d, got=%d", i, tc.expectedLine, tok.Pos.Line)
+		}
+
+		if tok.Pos.Column != tc.expectedColumn {
+			t.Errorf("tests[%d] - wrong column. expected=%d, got=%d", i, tc.expectedColumn, tok.Pos.Column)
+		}
+
+		if tok.Pos.Filename != "test.mk" {
+			t.Errorf("tests[%d] - wrong filename. expected=%q, got=%q", i, "test.mk", tok.Pos.Filename)
+		}
+	}
+}
+
+func TestNextTokenElseIf(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []token.Token
+	}{
+		{
+			input: "else if",
+			expected: []token.Token{
+				{Type: token.ELSEIF, Literal: "else if"},
+			},
+		},
+		{
+			input: "else \tif (",
+			expected: []token.Token{
+				{Type: token.ELSEIF, Literal: "else \tif"},
+				{Type: token.LPAREN, Literal: "("},
+			},
+		},
+		{
+			input: "elseif",
+			expected: []token.Token{
+				{Type: token.ELSEIF, Literal: "elseif"},
+			},
+		},
+		{
+			input: "else init",
+			expected: []token.Token{
+				{Type: token.ELSE, Literal: "else"},
+				{Type: token.IDENT, Literal: "init"},
+			},
+		},
+		{
+			input: "else iffy",
+			expected: []token.Token{
+				{Type: token.ELSE, Literal: "else"},
+				{Type: token.IDENT, Literal: "iffy"},
+			},
+		},
+		{
+			input: "elseiffy",
+			expected: []token.Token{
+				{Type: token.IDENT, Literal: "elseiffy"},
+			},
+		},
+		{
+			input: "else {",
+			expected: []token.Token{
+				{Type: token.ELSE, Literal: "else"},
+				{Type: token.LBRACE, Literal: "{"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		l := New(tc.input)
+
+		for i, expected := range append(tc.expected, token.Token{Type: token.EOF}) {
+			tok := l.NextToken()
+			if tok.Type != expected.Type {
+				t.Fatalf("%q: tokens[%d] - wrong tokenType. expected=%q, got=%q", tc.input, i, expected.Type, tok.Type)
+			}
+
+			if tok.Literal != expected.Literal {
+				t.Fatalf("%q: tokens[%d] - wrong literal. expected=%q, got=%q", tc.input, i, expected.Literal, tok.Literal)
+			}
+		}
+	}
+}
+
+func TestNextTokenStrings(t *testing.T) {
+	input := `"foobar" "foo bar" "a\n\t\"b\"\\" "\u{1F600}" "unterminated
+`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.STRING, `"foobar"`},
+		{token.STRING, `"foo bar"`},
+		{token.STRING, `"a\n\t\"b\"\\"`},
+		{token.STRING, `"\u{1F600}"`},
+		{token.ILLEGAL, `"unterminated`},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal. expected=%q, got=%q", i, tc.expectedLiteral, tok.Literal)
+		}
+	}
+
+	errors := l.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 lexer error. got: %d instead", len(errors))
+	}
+
+	if errors[0].Msg != "unterminated string literal" || errors[0].Pos.Column != 47 {
+		t.Errorf("wrong lexer error. got: %s", errors[0])
+	}
+}
+
+func TestNextTokenInvalidEscapes(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedMsg string
+	}{
+		{`"\q"`, `invalid escape sequence "\q" in string literal`},
+		{`"\u{}"`, `invalid unicode escape sequence "\u{}", expected 1 to 6 hex digits`},
+		{`"\u{zz}"`, `invalid unicode escape sequence "\u{zz}", expected 1 to 6 hex digits`},
+		{`"\u{D800}"`, `invalid unicode code point "\u{D800}" in string literal`},
+		{`"\u1234"`, `invalid unicode escape sequence in string literal, expected \u{X}`},
+		{`@`, `illegal character '@'`},
+	}
+
+	for _, tc := range tests {
+		l := New(tc.input)
+
+		tok := l.NextToken()
+		if tok.Type != token.ILLEGAL {
+			t.Errorf("%s: wrong tokenType. expected=%q, got=%q", tc.input, token.ILLEGAL, tok.Type)
+		}
+
+		errors := l.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("%s: expected 1 lexer error. got: %d instead", tc.input, len(errors))
+		}
+
+		if errors[0].Msg != tc.expectedMsg {
+			t.Errorf("%s: wrong error message. expected=%q, got=%q", tc.input, tc.expectedMsg, errors[0].Msg)
+		}
+	}
+}
+
+func TestUnquote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`""`, ""},
+		{`"foobar"`, "foobar"},
+		{`"a\nb"`, "a\nb"},
+		{`"a\tb"`, "a\tb"},
+		{`"say \"hi\""`, `say "hi"`},
+		{`"back\\slash"`, `back\slash`},
+		{`"\u{41}\u{e9}\u{1F600}"`, "Aé😀"},
+	}
+
+	for _, tc := range tests {
+		value, err := Unquote(tc.input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.input, err)
+			continue
+		}
+
+		if value != tc.expected {
+			t.Errorf("%s: wrong value. expected=%q, got=%q", tc.input, tc.expected, value)
+		}
+	}
+}
+
+func TestNextTokenComments(t *testing.T) {
+	input := `// leading comment
+let x = 5; // trailing comment
+/* block
+   /* nested */ comment */
+x / 2;`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+		withComments    bool
+	}{
+		{token.COMMENT, "// leading comment", true},
+		{token.LET, "let", false},
+		{token.IDENT, "x", false},
+		{token.ASSIGN, "=", false},
+		{token.INT, "5", false},
+		{token.SEMICOLON, ";", false},
+		{token.COMMENT, "// trailing comment", true},
+		{token.COMMENT, "/* block\n   /* nested */ comment */", true},
+		{token.IDENT, "x", false},
+		{token.SLASH, "/", false},
+		{token.INT, "2", false},
+		{token.SEMICOLON, ";", false},
+		{token.EOF, "", false},
+	}
+
+	for _, withComments := range []bool{false, true} {
+		var opts []Option
+		if withComments {
+			opts = append(opts, WithComments())
+		}
+
+		l := New(input, opts...)
+
+		for i, tc := range tests {
+			if tc.withComments && !withComments {
+				continue
+			}
+
+			tok := l.NextToken()
+			if tok.Type != tc.expectedType {
+				t.Fatalf("tests[%d] (comments: %t) - wrong tokenType. expected=%q, got=%q", i, withComments, tc.expectedType, tok.Type)
+			}
+
+			if tok.Literal != tc.expectedLiteral {
+				t.Fatalf("tests[%d] (comments: %t) - wrong literal. expected=%q, got=%q", i, withComments, tc.expectedLiteral, tok.Literal)
+			}
+		}
+	}
+}
+
+func TestNextTokenUnterminatedBlockComment(t *testing.T) {
+	l := New("x /* outer /* inner */")
+
+	if tok := l.NextToken(); tok.Type != token.IDENT {
+		t.Fatalf("wrong tokenType. expected=%q, got=%q", token.IDENT, tok.Type)
+	}
+
+	tok := l.NextToken()
+	if tok.Type != token.ILLEGAL {
+		t.Fatalf("wrong tokenType. expected=%q, got=%q", token.ILLEGAL, tok.Type)
+	}
+
+	if tok.Pos.Column != 3 {
+		t.Errorf("wrong column. expected=%d, got=%d", 3, tok.Pos.Column)
+	}
+
+	errors := l.Errors()
+	if len(errors) != 1 || errors[0].Msg != "unterminated block comment" {
+		t.Errorf("expected an unterminated block comment error. got: %v", errors)
+	}
+
+	if tok := l.NextToken(); tok.Type != token.EOF {
+		t.Fatalf("wrong tokenType. expected=%q, got=%q", token.EOF, tok.Type)
+	}
+}
+
+func TestNextTokenNumbers(t *testing.T) {
+	input := `5 3.14 1e9 1.5e-3 2E+10 0.5 1.x 1e 1.e3 7.foo`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.INT, "5"},
+		{token.FLOAT, "3.14"},
+		{token.FLOAT, "1e9"},
+		{token.FLOAT, "1.5e-3"},
+		{token.FLOAT, "2E+10"},
+		{token.FLOAT, "0.5"},
+		{token.INT, "1"},
+		{token.ILLEGAL, "."},
+		{token.IDENT, "x"},
+		{token.INT, "1"},
+		{token.IDENT, "e"},
+		{token.INT, "1"},
+		{token.ILLEGAL, "."},
+		{token.IDENT, "e"},
+		{token.INT, "3"},
+		{token.INT, "7"},
+		{token.ILLEGAL, "."},
+		{token.IDENT, "foo"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal. expected=%q, got=%q", i, tc.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenIntegerBases(t *testing.T) {
+	input := `0xFF 0XdeadBEEF 0o755 0b1010 1_000_000 0x_FF_FF 1_000.000_5 1e1_0`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.INT, "0xFF"},
+		{token.INT, "0XdeadBEEF"},
+		{token.INT, "0o755"},
+		{token.INT, "0b1010"},
+		{token.INT, "1_000_000"},
+		{token.INT, "0x_FF_FF"},
+		{token.FLOAT, "1_000.000_5"},
+		{token.FLOAT, "1e1_0"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal. expected=%q, got=%q", i, tc.expectedLiteral, tok.Literal)
+		}
+	}
+
+	if len(l.Errors()) != 0 {
+		t.Errorf("unexpected lexer errors: %v", l.Errors())
+	}
+}
+
+func TestNextTokenMalformedNumbers(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedLiteral string
+		expectedMsg     string
+	}{
+		{"0x", "0x", "invalid number literal 0x: hexadecimal literal has no digits"},
+		{"0b_", "0b_", "invalid number literal 0b_: binary literal has no digits"},
+		{"0b102", "0b102", "invalid number literal 0b102: invalid digit '2' in binary literal"},
+		{"0o78", "0o78", "invalid number literal 0o78: invalid digit '8' in octal literal"},
+		{"0xFG", "0xFG", "invalid number literal 0xFG: invalid digit 'G' in hexadecimal literal"},
+		{"1__0", "1__0", "invalid number literal 1__0: '_' must separate successive digits"},
+		{"1_", "1_", "invalid number literal 1_: '_' must separate successive digits"},
+		{"1_.5", "1_.5", "invalid number literal 1_.5: '_' must separate successive digits"},
+		{"0x__1", "0x__1", "invalid number literal 0x__1: '_' must separate successive digits"},
+		{"0xF_", "0xF_", "invalid number literal 0xF_: '_' must separate successive digits"},
+		{"010", "010", "invalid number literal 010: leading zeros are not allowed, use 0o for octal"},
+		{"0_7", "0_7", "invalid number literal 0_7: leading zeros are not allowed, use 0o for octal"},
+		{"09", "09", "invalid number literal 09: leading zeros are not allowed, use 0o for octal"},
+	}
+
+	for _, tc := range tests {
+		l := New("  " + tc.input + ";")
+
+		tok := l.NextToken()
+		if tok.Type != token.ILLEGAL {
+			t.Errorf("%s: wrong tokenType. expected=%q, got=%q", tc.input, token.ILLEGAL, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Errorf("%s: wrong literal. expected=%q, got=%q", tc.input, tc.expectedLiteral, tok.Literal)
+		}
+
+		errors := l.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("%s: expected 1 lexer error. got: %d instead", tc.input, len(errors))
+		}
+
+		if errors[0].Msg != tc.expectedMsg {
+			t.Errorf("%s: wrong error message. expected=%q, got=%q", tc.input, tc.expectedMsg, errors[0].Msg)
+		}
+
+		if errors[0].Pos.Column != 3 {
+			t.Errorf("%s: wrong error column. expected=%d, got=%d", tc.input, 3, errors[0].Pos.Column)
+		}
+
+		if tok := l.NextToken(); tok.Type != token.SEMICOLON {
+			t.Errorf("%s: wrong tokenType after number. expected=%q, got=%q", tc.input, token.SEMICOLON, tok.Type)
+		}
+	}
+}
+
+func TestNextTokenUnicode(t *testing.T) {
+	input := "let café = \"😀 ok\";\nnaïve + 日本;"
+
+	tests := []struct {
+		expectedType       token.TokenType
+		expectedLiteral    string
+		expectedColumn     int
+		expectedRuneColumn int
+	}{
+		{token.LET, "let", 1, 1},
+		{token.IDENT, "café", 5, 5},
+		{token.ASSIGN, "=", 11, 10},
+		{token.STRING, "\"😀 ok\"", 13, 12},
+		{token.SEMICOLON, ";", 22, 18},
+		{token.IDENT, "naïve", 1, 1},
+		{token.PLUS, "+", 8, 7},
+		{token.IDENT, "日本", 10, 9},
+		{token.SEMICOLON, ";", 16, 11},
+		{token.EOF, "", 17, 12},
+	}
+
+	l := New(input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal. expected=%q, got=%q", i, tc.expectedLiteral, tok.Literal)
+		}
+
+		if tok.Pos.Column != tc.expectedColumn {
+			t.Errorf("tests[%d] - wrong column. expected=%d, got=%d", i, tc.expectedColumn, tok.Pos.Column)
+		}
+
+		if tok.Pos.RuneColumn != tc.expectedRuneColumn {
+			t.Errorf("tests[%d] - wrong rune column. expected=%d, got=%d", i, tc.expectedRuneColumn, tok.Pos.RuneColumn)
+		}
+	}
+
+	if len(l.Errors()) != 0 {
+		t.Errorf("unexpected lexer errors: %v", l.Errors())
+	}
+}
+
+func TestNextTokenInvalidUTF8(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedLiteral string
+		expectedMsg     string
+	}{
+		{"\xff", "\xff", `invalid UTF-8 encoding "\xff"`},
+		{"\"a\xc3\"", "\"a\xc3\"", "invalid UTF-8 encoding in string literal"},
+		{"\x00", "\x00", "illegal character NUL"},
+		{"\"a\x00\"", "\"a\x00\"", ""},
+		{"€", "€", "illegal character '€'"},
+	}
+
+	for _, tc := range tests {
+		l := New(tc.input + " x")
+
+		tok := l.NextToken()
+		if tc.expectedMsg == "" {
+			if tok.Type != token.STRING || len(l.Errors()) != 0 {
+				t.Errorf("%q: expected a valid STRING token. got: %q with errors %v", tc.input, tok.Type, l.Errors())
+			}
+
+			continue
+		}
+
+		if tok.Type != token.ILLEGAL {
+			t.Errorf("%q: wrong tokenType. expected=%q, got=%q", tc.input, token.ILLEGAL, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Errorf("%q: wrong literal. expected=%q, got=%q", tc.input, tc.expectedLiteral, tok.Literal)
+		}
+
+		errors := l.Errors()
+		if len(errors) != 1 || errors[0].Msg != tc.expectedMsg {
+			t.Errorf("%q: expected error %q. got: %v", tc.input, tc.expectedMsg, errors)
+		}
+
+		// The lexer must recover and continue with the next token.
+		if tok := l.NextToken(); tok.Type != token.IDENT {
+			t.Errorf("%q: wrong tokenType after illegal token. expected=%q, got=%q", tc.input, token.IDENT, tok.Type)
+		}
+	}
+}
+
+func FuzzNextToken(f *testing.F) {
+	seeds := []string{
+		"let x = 5;",
+		"let café = \"😀\";",
+		"if (x) { y } else if (z) { w }",
+		"\"unterminated",
+		"/* nested /* comment */",
+		"0x 1__0 1.5e-3",
+		"\xff\xfe\x00",
+	}
+
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		l := New(input)
+
+		lastOffset := -1
+
+		// Every call to NextToken must make progress so that the loop terminates.
+		for i := 0; i <= len(input)+1; i++ {
+			tok := l.NextToken()
+
+			if tok.Pos.Offset < 0 || tok.Pos.Offset+len(tok.Literal) > len(input) {
+				t.Fatalf("token %+v is out of the input bounds", tok)
+			}
+
+			if tok.Type == token.EOF {
+				return
+			}
+
+			if tok.Pos.Offset <= lastOffset {
+				t.Fatalf("token %+v does not advance past offset %d", tok, lastOffset)
+			}
+
+			if input[tok.Pos.Offset:tok.Pos.Offset+len(tok.Literal)] != tok.Literal {
+				t.Fatalf("token %+v literal does not match the input", tok)
+			}
+
+			lastOffset = tok.Pos.Offset
+		}
+
+		t.Fatalf("lexer did not reach EOF")
+	})
+}
+
+func TestNextTokenComparisonAndLogicalOperators(t *testing.T) {
+	input := `x <= 10 >= y && a || b % 2 < > & |`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "x"},
+		{token.LTE, "<="},
+		{token.INT, "10"},
+		{token.GTE, ">="},
+		{token.IDENT, "y"},
+		{token.AND, "&&"},
+		{token.IDENT, "a"},
+		{token.OR, "||"},
+		{token.IDENT, "b"},
+		{token.MODULO, "%"},
+		{token.INT, "2"},
+		{token.LT, "<"},
+		{token.GT, ">"},
+		{token.BITAND, "&"},
+		{token.BITOR, "|"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal. expected=%q, got=%q", i, tc.expectedLiteral, tok.Literal)
+		}
+	}
+
+	if len(l.Errors()) != 0 {
+		t.Errorf("unexpected lexer errors: %v", l.Errors())
+	}
+}
+
+func TestNextTokenBitwiseOperators(t *testing.T) {
+	input := `a & b | c ^ ~d << 2 >> 1 <<= >>= && ||`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "a"},
+		{token.BITAND, "&"},
+		{token.IDENT, "b"},
+		{token.BITOR, "|"},
+		{token.IDENT, "c"},
+		{token.BITXOR, "^"},
+		{token.BITNOT, "~"},
+		{token.IDENT, "d"},
+		{token.SHL, "<<"},
+		{token.INT, "2"},
+		{token.SHR, ">>"},
+		{token.INT, "1"},
+		{token.SHL, "<<"},
+		{token.ASSIGN, "="},
+		{token.SHR, ">>"},
+		{token.ASSIGN, "="},
+		{token.AND, "&&"},
+		{token.OR, "||"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal. expected=%q, got=%q", i, tc.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenBracketsAndColons(t *testing.T) {
+	input := `[1, 2][0]; {"one": 1}`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LBRACKET, "["},
+		{token.INT, "1"},
+		{token.COMMA, ","},
+		{token.INT, "2"},
+		{token.RBRACKET, "]"},
+		{token.LBRACKET, "["},
+		{token.INT, "0"},
+		{token.RBRACKET, "]"},
+		{token.SEMICOLON, ";"},
+		{token.LBRACE, "{"},
+		{token.STRING, `"one"`},
+		{token.COLON, ":"},
+		{token.INT, "1"},
+		{token.RBRACE, "}"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal. expected=%q, got=%q", i, tc.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenAssignmentOperators(t *testing.T) {
+	input := `x = 1; x += 2; x -= 3; x *= 4; x /= 5; x == y; x /* c */ /= 6`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.INT, "1"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.PLUS_ASSIGN, "+="},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.MINUS_ASSIGN, "-="},
+		{token.INT, "3"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.ASTERISK_ASSIGN, "*="},
+		{token.INT, "4"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.SLASH_ASSIGN, "/="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.EQ, "=="},
+		{token.IDENT, "y"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.SLASH_ASSIGN, "/="},
+		{token.INT, "6"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal. expected=%q, got=%q", i, tc.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenLoopKeywords(t *testing.T) {
+	input := `while for in break continue inner forx`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.WHILE, "while"},
+		{token.FOR, "for"},
+		{token.IN, "in"},
+		{token.BREAK, "break"},
+		{token.CONTINUE, "continue"},
+		{token.IDENT, "inner"},
+		{token.IDENT, "forx"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("tests[%d] - wrong tokenType. expected=%q, got=%q", i, tc.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal. expected=%q, got=%q", i, tc.expectedLiteral, tok.Literal)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// defaultBufferSize is the number of bytes read from an io.Reader at a time.
+const defaultBufferSize = 4096
+
+// WithBufferSize configures the number of bytes a streaming lexer reads from its
+// io.Reader at a time. It has no effect on lexers created from a string.
+func WithBufferSize(size int) Option {
+	return func(l *Lexer) {
+		if size >= utf8.UTFMax {
+			l.bufferSize = size
+		}
+	}
+}
+
+// NewReader returns an initialized instance of a Lexer that reads its input from r as
+// tokens are requested. Only the source of the token being lexed and a bounded read
+// buffer are kept in memory which allows lexing inputs of any size.
+func NewReader(r io.Reader, opts ...Option) *Lexer {
+	return NewReaderWithFilename("", r, opts...)
+}
+
+// NewReaderWithFilename returns an initialized instance of a streaming Lexer whose token
+// positions report the provided filename.
+func NewReaderWithFilename(filename string, r io.Reader, opts ...Option) *Lexer {
+	l := &Lexer{filename: filename, line: 1, reader: r, bufferSize: defaultBufferSize}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	// Set all the remaining lexer fields by calling l.readChar.
+	l.readChar()
+
+	return l
+}
+
+// ensure reads from the reader until the input holds at least end bytes or the reader
+// is exhausted. It reports whether the input holds at least end bytes.
+func (l *Lexer) ensure(end int) bool {
+	for l.reader != nil && len(l.input) < end {
+		l.fill()
+	}
+
+	return len(l.input) >= end
+}
+
+// ensureRune reads from the reader until the input holds the complete UTF-8 encoding of
+// the char at position or the reader is exhausted. It reports whether a char is
+// available at position. Only the bytes of that char are waited for so that interactive
+// input is lexed as soon as it arrives.
+func (l *Lexer) ensureRune(position int) bool {
+	if !l.ensure(position + 1) {
+		return false
+	}
+
+	for l.reader != nil && !utf8.FullRuneInString(l.input[position:]) {
+		l.fill()
+	}
+
+	return true
+}
+
+// fill appends the next chunk of the reader to the input. The reader is dropped once
+// it is exhausted or returns an error.
+func (l *Lexer) fill() {
+	if l.chunk == nil {
+		l.chunk = make([]byte, l.bufferSize)
+	}
+
+	n, err := l.reader.Read(l.chunk)
+	if n > 0 {
+		l.input += string(l.chunk[:n])
+	}
+
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			pos := l.pos()
+			pos.Offset = l.base + len(l.input)
+			l.readErr = &Error{Pos: pos, Msg: fmt.Sprintf("read error: %s", err)}
+			l.addError(pos, l.readErr.Msg)
+		}
+
+		l.reader = nil
+	}
+}
+
+// ReadError returns the error that stopped a streaming lexer from reading its input, or
+// nil if the input was read completely. The lexer returns an EOF token once the input
+// read before the error is exhausted, so a caller must check ReadError to tell a
+// truncated input from a complete one. The error is also included in Errors.
+func (l *Lexer) ReadError() *Error {
+	return l.readErr
+}
+
+// compact discards the part of the input before the current char. It is called between
+// tokens so that no token literal refers to the discarded part. The discarded part is
+// only released once it makes up more than half of the input to avoid copying the input
+// for every token. The next call to fill copies the remaining input into a new string
+// which releases the memory of the discarded part.
+func (l *Lexer) compact() {
+	if l.reader == nil || l.position < len(l.input)/2 || l.position < l.bufferSize {
+		return
+	}
+
+	l.input = l.input[l.position:]
+	l.base += l.position
+	l.lineStart -= l.position
+	l.readPosition -= l.position
+	l.position = 0
+}
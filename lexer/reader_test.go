@@ -0,0 +1,173 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/mycok/monkey_interpreter/token"
+)
+
+const streamInput = `let café = "😀 \"quoted\"";
+// line comment
+let add = fn(x, y) { x + y; };
+/* block /* nested */ comment */
+if (add(1, 2) > 2) { 0xFF } else   if (true) { 1_000 } else { 1.5e-3 };
+"unterminated
+else init` + "\xff"
+
+func TestNewReaderMatchesNew(t *testing.T) {
+	readers := map[string]func() io.Reader{
+		"strings.Reader": func() io.Reader { return strings.NewReader(streamInput) },
+		"one byte":       func() io.Reader { return iotest.OneByteReader(strings.NewReader(streamInput)) },
+		"half":           func() io.Reader { return iotest.HalfReader(strings.NewReader(streamInput)) },
+	}
+
+	for name, newReader := range readers {
+		for _, bufferSize := range []int{4, 7, 64, defaultBufferSize} {
+			expected := New(streamInput, WithComments())
+			l := NewReader(newReader(), WithComments(), WithBufferSize(bufferSize))
+
+			for i := 0; ; i++ {
+				want := expected.NextToken()
+				got := l.NextToken()
+
+				if got != want {
+					t.Fatalf("%s (buffer size %d): tokens[%d] - expected=%+v, got=%+v", name, bufferSize, i, want, got)
+				}
+
+				if want.Type == token.EOF {
+					break
+				}
+			}
+
+			if len(l.Errors()) != len(expected.Errors()) {
+				t.Fatalf("%s (buffer size %d): expected %d errors. got: %d instead", name, bufferSize, len(expected.Errors()), len(l.Errors()))
+			}
+
+			for i, err := range l.Errors() {
+				if err != expected.Errors()[i] {
+					t.Errorf("%s (buffer size %d): errors[%d] - expected=%v, got=%v", name, bufferSize, i, expected.Errors()[i], err)
+				}
+			}
+		}
+	}
+}
+
+func TestNewReaderBoundedBuffer(t *testing.T) {
+	const size = 1 << 20
+
+	l := NewReader(newRepeatReader("let x = 5 + y; ", size), WithBufferSize(256))
+
+	maxInput := 0
+
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		if len(l.input) > maxInput {
+			maxInput = len(l.input)
+		}
+	}
+
+	if maxInput > 4*256 {
+		t.Errorf("expected the lexer input to stay bounded. got: %d bytes for a %d byte input", maxInput, size)
+	}
+}
+
+func TestNewReaderError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("let x"), iotest.ErrReader(errors.New("boom")))
+	l := NewReaderWithFilename("script.mk", r)
+
+	tests := []token.TokenType{token.LET, token.IDENT, token.EOF}
+
+	for i, expected := range tests {
+		if tok := l.NextToken(); tok.Type != expected {
+			t.Fatalf("tokens[%d] - wrong tokenType. expected=%q, got=%q", i, expected, tok.Type)
+		}
+	}
+
+	errors := l.Errors()
+	if len(errors) != 1 || errors[0].Msg != "read error: boom" || errors[0].Pos.Filename != "script.mk" {
+		t.Errorf("expected a read error. got: %v", errors)
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	for _, size := range []int{1 << 20, 8 << 20} {
+		input, _ := io.ReadAll(newRepeatReader(benchmarkLine, size))
+
+		b.Run(byteSize(size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				l := New(string(input))
+				for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkNewReader reports the largest input window held by the lexer which stays
+// constant regardless of the size of the input.
+func BenchmarkNewReader(b *testing.B) {
+	for _, size := range []int{1 << 20, 8 << 20} {
+		b.Run(byteSize(size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+
+			maxInput := 0
+
+			for i := 0; i < b.N; i++ {
+				l := NewReader(newRepeatReader(benchmarkLine, size))
+				for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+					if len(l.input) > maxInput {
+						maxInput = len(l.input)
+					}
+				}
+			}
+
+			b.ReportMetric(float64(maxInput), "max-buffer-bytes")
+		})
+	}
+}
+
+const benchmarkLine = "let result = add(five, ten) * 0xFF; // \"comment\"\n"
+
+func byteSize(size int) string {
+	return fmt.Sprintf("%dMB", size>>20)
+}
+
+// repeatReader generates size bytes by repeating a line without holding the generated
+// input in memory.
+type repeatReader struct {
+	line      string
+	remaining int
+	offset    int
+}
+
+func newRepeatReader(line string, size int) *repeatReader {
+	return &repeatReader{line: line, remaining: size}
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		return 0, io.EOF
+	}
+
+	n := 0
+	for n < len(p) && r.remaining > 0 {
+		c := copy(p[n:], r.line[r.offset:])
+		if c > r.remaining {
+			c = r.remaining
+		}
+
+		n += c
+		r.remaining -= c
+		r.offset = (r.offset + c) % len(r.line)
+	}
+
+	return n, nil
+}
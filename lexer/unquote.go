@@ -0,0 +1,88 @@
+package lexer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Unquote interprets lit as a double quoted monkey string literal and returns the string
+// value that lit represents. The supported escape sequences are \n, \t, \", \\ and
+// \u{X} where X is the hexadecimal value of a unicode code point.
+func Unquote(lit string) (string, error) {
+	if len(lit) < 2 || lit[0] != '"' || lit[len(lit)-1] != '"' {
+		return "", fmt.Errorf("invalid string literal %s", lit)
+	}
+
+	s := lit[1 : len(lit)-1]
+
+	// Fast path for strings without escape sequences.
+	if !strings.ContainsRune(s, '\\') {
+		return s, nil
+	}
+
+	var out strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			out.WriteByte(s[i])
+			continue
+		}
+
+		if i+1 >= len(s) {
+			return "", fmt.Errorf("unterminated escape sequence in string literal")
+		}
+
+		i++
+
+		switch s[i] {
+		case 'n':
+			out.WriteByte('\n')
+		case 't':
+			out.WriteByte('\t')
+		case '"':
+			out.WriteByte('"')
+		case '\\':
+			out.WriteByte('\\')
+		case 'u':
+			r, n, err := unquoteUnicode(s[i+1:])
+			if err != nil {
+				return "", err
+			}
+
+			out.WriteRune(r)
+			i += n
+		default:
+			return "", fmt.Errorf("invalid escape sequence \"\\%c\" in string literal", s[i])
+		}
+	}
+
+	return out.String(), nil
+}
+
+// unquoteUnicode decodes the "{X}" part of a \u{X} escape sequence at the start of s and
+// returns the code point together with the number of bytes consumed.
+func unquoteUnicode(s string) (rune, int, error) {
+	end := strings.IndexByte(s, '}')
+	if !strings.HasPrefix(s, "{") || end < 0 {
+		return 0, 0, fmt.Errorf("invalid unicode escape sequence in string literal, expected \\u{X}")
+	}
+
+	hex := s[1:end]
+	if len(hex) == 0 || len(hex) > 6 {
+		return 0, 0, fmt.Errorf("invalid unicode escape sequence \"\\u%s\", expected 1 to 6 hex digits", s[:end+1])
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid unicode escape sequence \"\\u%s\", expected 1 to 6 hex digits", s[:end+1])
+	}
+
+	r := rune(value)
+	if !utf8.ValidRune(r) {
+		return 0, 0, fmt.Errorf("invalid unicode code point \"\\u%s\" in string literal", s[:end+1])
+	}
+
+	return r, end + 1, nil
+}
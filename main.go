@@ -5,10 +5,19 @@ import (
 	"os"
 	"os/user"
 
+	"github.com/mycok/monkey_interpreter/diagnostic"
+	"github.com/mycok/monkey_interpreter/evaluator"
+	"github.com/mycok/monkey_interpreter/lexer"
+	"github.com/mycok/monkey_interpreter/object"
+	"github.com/mycok/monkey_interpreter/parser"
 	"github.com/mycok/monkey_interpreter/repl"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(runFile(os.Args[1]))
+	}
+
 	user, err := user.Current()
 	if err != nil {
 		panic(err)
@@ -19,3 +28,31 @@ func main() {
 
 	repl.Start(os.Stdin, os.Stdout)
 }
+
+// runFile parses and evaluates the monkey script at path and returns the process exit code.
+func runFile(path string) int {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	p := parser.New(lexer.NewWithFilename(path, string(src)))
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		diagnostic.Render(os.Stderr, string(src), p.Errors())
+
+		return 1
+	}
+
+	evaluated := evaluator.Eval(program, object.NewEnvironment())
+	if errObj, ok := evaluated.(*object.Error); ok {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, errObj.Message)
+
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,52 @@
+package object
+
+// Environment represents a scope that maps identifiers to their bound values.
+type Environment struct {
+	store map[string]Object
+	outer *Environment
+}
+
+// NewEnvironment returns an initialized instance of an Environment.
+func NewEnvironment() *Environment {
+	return &Environment{store: make(map[string]Object)}
+}
+
+// NewEnclosedEnvironment returns an initialized instance of an Environment that falls
+// back to outer for identifiers it does not contain.
+func NewEnclosedEnvironment(outer *Environment) *Environment {
+	env := NewEnvironment()
+	env.outer = outer
+
+	return env
+}
+
+// Get returns the value bound to name in e or any of its outer environments.
+func (e *Environment) Get(name string) (Object, bool) {
+	obj, ok := e.store[name]
+	if !ok && e.outer != nil {
+		return e.outer.Get(name)
+	}
+
+	return obj, ok
+}
+
+// Assign rebinds name in the innermost environment that already contains it and returns
+// val. It reports false when name is not bound in e or any of its outer environments.
+func (e *Environment) Assign(name string, val Object) (Object, bool) {
+	if _, ok := e.store[name]; ok {
+		return e.Set(name, val), true
+	}
+
+	if e.outer != nil {
+		return e.outer.Assign(name, val)
+	}
+
+	return nil, false
+}
+
+// Set binds val to name in e and returns val.
+func (e *Environment) Set(name string, val Object) Object {
+	e.store[name] = val
+
+	return val
+}
@@ -0,0 +1,278 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mycok/monkey_interpreter/ast"
+)
+
+const (
+	// INTEGER_OBJ ... are the types of values produced by the evaluator.
+	INTEGER_OBJ      = "INTEGER"
+	FLOAT_OBJ        = "FLOAT"
+	BOOLEAN_OBJ      = "BOOLEAN"
+	STRING_OBJ       = "STRING"
+	NULL_OBJ         = "NULL"
+	RETURN_VALUE_OBJ = "RETURN_VALUE"
+	ERROR_OBJ        = "ERROR"
+	FUNCTION_OBJ     = "FUNCTION"
+	ARRAY_OBJ        = "ARRAY"
+	HASH_OBJ         = "HASH"
+	BREAK_OBJ        = "BREAK"
+	CONTINUE_OBJ     = "CONTINUE"
+)
+
+// ObjectType represents the type of an object.
+type ObjectType string
+
+// Object interface is implemented by every value produced by the evaluator.
+type Object interface {
+	Type() ObjectType
+	Inspect() string
+}
+
+// Integer represents a 64 bit signed integer value.
+type Integer struct {
+	Value int64
+}
+
+// Type returns the type of the object.
+func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
+
+// Inspect returns a string representation of the Integer value.
+func (i *Integer) Inspect() string { return fmt.Sprintf("%d", i.Value) }
+
+// Float represents a 64 bit floating point value.
+type Float struct {
+	Value float64
+}
+
+// Type returns the type of the object.
+func (f *Float) Type() ObjectType { return FLOAT_OBJ }
+
+// Inspect returns a string representation of the Float value. Whole numbers keep a
+// trailing ".0" so that they can be told apart from integers.
+func (f *Float) Inspect() string {
+	s := strconv.FormatFloat(f.Value, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eIN") {
+		s += ".0"
+	}
+
+	return s
+}
+
+// Boolean represents a boolean value.
+type Boolean struct {
+	Value bool
+}
+
+// Type returns the type of the object.
+func (b *Boolean) Type() ObjectType { return BOOLEAN_OBJ }
+
+// Inspect returns a string representation of the Boolean value.
+func (b *Boolean) Inspect() string { return fmt.Sprintf("%t", b.Value) }
+
+// String represents a string value.
+type String struct {
+	Value string
+}
+
+// Type returns the type of the object.
+func (s *String) Type() ObjectType { return STRING_OBJ }
+
+// Inspect returns a string representation of the String value.
+func (s *String) Inspect() string { return s.Value }
+
+// Null represents the absence of a value.
+type Null struct{}
+
+// Type returns the type of the object.
+func (n *Null) Type() ObjectType { return NULL_OBJ }
+
+// Inspect returns a string representation of the Null value.
+func (n *Null) Inspect() string { return "null" }
+
+// ReturnValue wraps the value of a return statement so that the evaluator can stop
+// evaluating the remaining statements of a program or function body.
+type ReturnValue struct {
+	Value Object
+}
+
+// Type returns the type of the object.
+func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
+
+// Inspect returns a string representation of the wrapped value.
+func (rv *ReturnValue) Inspect() string { return rv.Value.Inspect() }
+
+// Break signals that the enclosing loop should stop iterating.
+type Break struct{}
+
+// Type returns the type of the object.
+func (b *Break) Type() ObjectType { return BREAK_OBJ }
+
+// Inspect returns a string representation of the Break value.
+func (b *Break) Inspect() string { return "break" }
+
+// Continue signals that the enclosing loop should skip to its next iteration.
+type Continue struct{}
+
+// Type returns the type of the object.
+func (c *Continue) Type() ObjectType { return CONTINUE_OBJ }
+
+// Inspect returns a string representation of the Continue value.
+func (c *Continue) Inspect() string { return "continue" }
+
+// Error represents an error encountered while evaluating a program.
+type Error struct {
+	Message string
+}
+
+// Type returns the type of the object.
+func (e *Error) Type() ObjectType { return ERROR_OBJ }
+
+// Inspect returns a string representation of the Error value.
+func (e *Error) Inspect() string { return "ERROR: " + e.Message }
+
+// Function represents a function value together with the environment it was defined in.
+type Function struct {
+	Parameters []*ast.Identifier
+	Body       *ast.BlockStatement
+	Env        *Environment
+}
+
+// Type returns the type of the object.
+func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
+
+// Inspect returns a string representation of the Function value.
+func (f *Function) Inspect() string {
+	var out bytes.Buffer
+
+	params := make([]string, 0, len(f.Parameters))
+	for _, p := range f.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString("fn(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") {\n")
+	out.WriteString(f.Body.String())
+	out.WriteString("\n}")
+
+	return out.String()
+}
+
+// Array represents an ordered list of values.
+type Array struct {
+	Elements []Object
+}
+
+// Type returns the type of the object.
+func (a *Array) Type() ObjectType { return ARRAY_OBJ }
+
+// Inspect returns a string representation of the Array value.
+func (a *Array) Inspect() string {
+	var out bytes.Buffer
+
+	elements := make([]string, 0, len(a.Elements))
+	for _, el := range a.Elements {
+		elements = append(elements, el.Inspect())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+// HashKey represents the key under which a hashable value is stored in a Hash. Two
+// hashable values have the same HashKey only if they are equal.
+type HashKey struct {
+	Type  ObjectType
+	Value uint64 // Value of an Integer or Boolean key.
+	Text  string // Value of a String key.
+}
+
+// Hashable is implemented by objects that can be used as Hash keys.
+type Hashable interface {
+	HashKey() HashKey
+}
+
+// HashKey returns the hash key of the Integer value.
+func (i *Integer) HashKey() HashKey {
+	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
+}
+
+// HashKey returns the hash key of the Boolean value.
+func (b *Boolean) HashKey() HashKey {
+	var value uint64
+	if b.Value {
+		value = 1
+	}
+
+	return HashKey{Type: b.Type(), Value: value}
+}
+
+// HashKey returns the hash key of the String value. Strings are keyed by their value
+// rather than a digest of it so that different strings never collide.
+func (s *String) HashKey() HashKey {
+	return HashKey{Type: s.Type(), Text: s.Value}
+}
+
+// HashPair holds the original key object together with its value.
+type HashPair struct {
+	Key   Object
+	Value Object
+}
+
+// Hash represents a map of hashable keys to values. Keys records the insertion order
+// of the pairs so that inspecting and iterating a hash is deterministic.
+type Hash struct {
+	Pairs map[HashKey]HashPair
+	Keys  []HashKey
+}
+
+// NewHash returns an empty Hash.
+func NewHash() *Hash {
+	return &Hash{Pairs: make(map[HashKey]HashPair)}
+}
+
+// Get returns the value stored under key and whether it was found.
+func (h *Hash) Get(key Hashable) (Object, bool) {
+	pair, ok := h.Pairs[key.HashKey()]
+
+	return pair.Value, ok
+}
+
+// Set stores value under key, replacing any previous value.
+func (h *Hash) Set(key Object, value Object) {
+	hashKey := key.(Hashable).HashKey()
+	if _, ok := h.Pairs[hashKey]; !ok {
+		h.Keys = append(h.Keys, hashKey)
+	}
+
+	h.Pairs[hashKey] = HashPair{Key: key, Value: value}
+}
+
+// Type returns the type of the object.
+func (h *Hash) Type() ObjectType { return HASH_OBJ }
+
+// Inspect returns a string representation of the Hash value.
+func (h *Hash) Inspect() string {
+	var out bytes.Buffer
+
+	pairs := make([]string, 0, len(h.Keys))
+	for _, key := range h.Keys {
+		pair := h.Pairs[key]
+		pairs = append(pairs, pair.Key.Inspect()+": "+pair.Value.Inspect())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
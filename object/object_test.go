@@ -0,0 +1,55 @@
+package object
+
+import "testing"
+
+func TestStringHashKey(t *testing.T) {
+	hello1 := &String{Value: "Hello World"}
+	hello2 := &String{Value: "Hello World"}
+	diff := &String{Value: "My name is johnny"}
+
+	if hello1.HashKey() != hello2.HashKey() {
+		t.Errorf("strings with same content have different hash keys")
+	}
+
+	if hello1.HashKey() == diff.HashKey() {
+		t.Errorf("strings with different content have same hash keys")
+	}
+}
+
+func TestHashSetAndGet(t *testing.T) {
+	hash := NewHash()
+
+	hash.Set(&String{Value: "one"}, &Integer{Value: 1})
+	hash.Set(&String{Value: "two"}, &Integer{Value: 2})
+	hash.Set(&Integer{Value: 1}, &Integer{Value: 3})
+	hash.Set(&String{Value: "one"}, &Integer{Value: 4})
+
+	tests := []struct {
+		key      Hashable
+		expected int64
+	}{
+		{&String{Value: "one"}, 4},
+		{&String{Value: "two"}, 2},
+		{&Integer{Value: 1}, 3},
+	}
+
+	for _, tc := range tests {
+		value, ok := hash.Get(tc.key)
+		if !ok {
+			t.Errorf("no value found for %v", tc.key)
+			continue
+		}
+
+		if value.(*Integer).Value != tc.expected {
+			t.Errorf("wrong value for %v. expected: %d, got: %d instead", tc.key, tc.expected, value.(*Integer).Value)
+		}
+	}
+
+	if len(hash.Keys) != 3 {
+		t.Errorf("hash.Keys expected to contain 3 keys. got: %d instead", len(hash.Keys))
+	}
+
+	if _, ok := hash.Get(&Boolean{Value: true}); ok {
+		t.Errorf("expected no value for true")
+	}
+}
@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mycok/monkey_interpreter/token"
+)
+
+// ErrorKind represents the category of a ParseError.
+type ErrorKind int
+
+const (
+	// UnexpectedToken is reported when the parser expects a token of a specific type
+	// but encounters a different one.
+	UnexpectedToken ErrorKind = iota
+
+	// NoPrefixParseFn is reported when a token can not start an expression.
+	NoPrefixParseFn
+
+	// InvalidLiteral is reported when a literal token can not be converted into its value.
+	InvalidLiteral
+
+	// IllegalToken is reported when the lexer produces an ILLEGAL token such as an
+	// unterminated string literal.
+	IllegalToken
+
+	// InvalidAssignment is reported when the left side of an assignment is neither an
+	// identifier nor an index expression.
+	InvalidAssignment
+
+	// ReadError is reported when the input of a streaming lexer could not be read
+	// completely. The program then only contains the statements read before the error.
+	ReadError
+)
+
+var errorKindNames = map[ErrorKind]string{
+	UnexpectedToken:   "unexpected token",
+	NoPrefixParseFn:   "no prefix parse function",
+	InvalidLiteral:    "invalid literal",
+	IllegalToken:      "illegal token",
+	InvalidAssignment: "invalid assignment",
+	ReadError:         "read error",
+}
+
+// String returns a human readable name of the error kind.
+func (k ErrorKind) String() string {
+	if name, ok := errorKindNames[k]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("ErrorKind(%d)", int(k))
+}
+
+// ParseError represents a single error encountered by the parser.
+type ParseError struct {
+	Kind     ErrorKind
+	Expected token.TokenType // Expected token type. Only set for UnexpectedToken errors.
+	Got      token.TokenType // Type of the offending token.
+	Pos      token.Pos       // Position of the first character of the offending token.
+	End      token.Pos       // Position immediately after the offending token.
+	Msg      string
+	Hint     string // Optional suggestion on how to fix the error.
+}
+
+// Error returns the error message prefixed with the error position.
+func (e *ParseError) Error() string {
+	if e.Pos.IsValid() || e.Pos.Filename != "" {
+		return e.Pos.String() + ": " + e.Msg
+	}
+
+	return e.Msg
+}
+
+// String returns the bare error message without the error position.
+func (e *ParseError) String() string { return e.Msg }
+
+// ErrorList represents a list of parse errors. The zero value is an empty list ready to use.
+type ErrorList []*ParseError
+
+// Add appends a ParseError to the list.
+func (l *ErrorList) Add(err *ParseError) { *l = append(*l, err) }
+
+// Len, Swap and Less implement the sort.Interface.
+func (l ErrorList) Len() int      { return len(l) }
+func (l ErrorList) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
+
+func (l ErrorList) Less(i, j int) bool {
+	e, f := l[i].Pos, l[j].Pos
+
+	if e.Filename != f.Filename {
+		return e.Filename < f.Filename
+	}
+
+	if e.Offset != f.Offset {
+		return e.Offset < f.Offset
+	}
+
+	return l[i].Msg < l[j].Msg
+}
+
+// Sort sorts the list by filename, source position and message.
+func (l ErrorList) Sort() { sort.Sort(l) }
+
+// Deduplicate sorts the list and removes errors that share the same position and message.
+func (l *ErrorList) Deduplicate() {
+	l.Sort()
+
+	var (
+		last token.Pos
+		msg  string
+		i    int
+	)
+
+	for _, e := range *l {
+		if i == 0 || e.Pos != last || e.Msg != msg {
+			last = e.Pos
+			msg = e.Msg
+			(*l)[i] = e
+			i++
+		}
+	}
+
+	*l = (*l)[:i]
+}
+
+// Error returns a string representation of the list which implements the error interface.
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// String returns all the error messages separated by new lines.
+func (l ErrorList) String() string {
+	msgs := make([]string, len(l))
+
+	for i, e := range l {
+		msgs[i] = e.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Err returns an error equivalent to the list or nil if the list is empty.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+
+	return l
+}
@@ -0,0 +1,297 @@
+package parser
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/mycok/monkey_interpreter/ast"
+	"github.com/mycok/monkey_interpreter/lexer"
+	"github.com/mycok/monkey_interpreter/token"
+)
+
+func TestParseErrors(t *testing.T) {
+	input := "let x 5;\nlet = 10;"
+
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) < 2 {
+		t.Fatalf("expected at least 2 errors. got: %d instead", len(errors))
+	}
+
+	tests := []struct {
+		kind      ErrorKind
+		expected  token.TokenType
+		got       token.TokenType
+		line      int
+		column    int
+		endColumn int
+		msg       string
+		errString string
+	}{
+		{
+			kind:      UnexpectedToken,
+			expected:  token.ASSIGN,
+			got:       token.INT,
+			line:      1,
+			column:    7,
+			endColumn: 8,
+			msg:       "expected next token to be =, got: INT instead",
+			errString: "1:7: expected next token to be =, got: INT instead",
+		},
+		{
+			kind:      UnexpectedToken,
+			expected:  token.IDENT,
+			got:       token.ASSIGN,
+			line:      2,
+			column:    5,
+			endColumn: 6,
+			msg:       "expected next token to be IDENT, got: = instead",
+			errString: "2:5: expected next token to be IDENT, got: = instead",
+		},
+	}
+
+	for i, tc := range tests {
+		err := errors[i]
+
+		if err.Kind != tc.kind {
+			t.Errorf("tests[%d] - wrong kind. expected=%s, got=%s", i, tc.kind, err.Kind)
+		}
+
+		if err.Expected != tc.expected {
+			t.Errorf("tests[%d] - wrong expected type. expected=%q, got=%q", i, tc.expected, err.Expected)
+		}
+
+		if err.Got != tc.got {
+			t.Errorf("tests[%d] - wrong got type. expected=%q, got=%q", i, tc.got, err.Got)
+		}
+
+		if err.Pos.Line != tc.line || err.Pos.Column != tc.column {
+			t.Errorf("tests[%d] - wrong position. expected=%d:%d, got=%s", i, tc.line, tc.column, err.Pos)
+		}
+
+		if err.End.Column != tc.endColumn {
+			t.Errorf("tests[%d] - wrong end column. expected=%d, got=%d", i, tc.endColumn, err.End.Column)
+		}
+
+		if err.String() != tc.msg {
+			t.Errorf("tests[%d] - wrong String(). expected=%q, got=%q", i, tc.msg, err.String())
+		}
+
+		if err.Error() != tc.errString {
+			t.Errorf("tests[%d] - wrong Error(). expected=%q, got=%q", i, tc.errString, err.Error())
+		}
+	}
+}
+
+func TestErrorListSortAndDeduplicate(t *testing.T) {
+	pos := func(offset int) token.Pos {
+		return token.Pos{Offset: offset, Line: 1, Column: offset + 1}
+	}
+
+	list := ErrorList{}
+	list.Add(&ParseError{Pos: pos(9), Msg: "c"})
+	list.Add(&ParseError{Pos: pos(2), Msg: "b"})
+	list.Add(&ParseError{Pos: pos(2), Msg: "a"})
+	list.Add(&ParseError{Pos: pos(9), Msg: "c"})
+
+	list.Deduplicate()
+
+	expected := []string{"1:3: a", "1:3: b", "1:10: c"}
+
+	if len(list) != len(expected) {
+		t.Fatalf("expected %d errors after Deduplicate. got: %d instead", len(expected), len(list))
+	}
+
+	for i, e := range expected {
+		if list[i].Error() != e {
+			t.Errorf("list[%d].Error() is not %q. got: %q instead", i, e, list[i].Error())
+		}
+	}
+
+	if list.Err() == nil {
+		t.Errorf("list.Err() is nil for a non empty list")
+	}
+
+	if (ErrorList{}).Err() != nil {
+		t.Errorf("ErrorList{}.Err() is not nil for an empty list")
+	}
+}
+
+func TestParseErrorsAreSorted(t *testing.T) {
+	p := New(lexer.New("let x = ) @;"))
+	p.ParseProgram()
+
+	errs := p.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors. got: %d instead: %s", len(errs), errs)
+	}
+
+	if errs[0].Kind != NoPrefixParseFn || errs[1].Kind != IllegalToken {
+		t.Errorf("errors are not sorted by position. got: %s, %s instead", errs[0].Kind, errs[1].Kind)
+	}
+}
+
+func TestParseErrorRecovery(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedErrors int
+		expected       string
+	}{
+		{
+			input:          "let x 5; let y = 10; let = 3; let z = 1;",
+			expectedErrors: 2,
+			expected:       "(bad) (let y 10) (bad) (let z 1)",
+		},
+		{
+			input:          "let x = 1 + ; x;",
+			expectedErrors: 1,
+			expected:       "(let x (+ 1 (bad))) x",
+		},
+		{
+			input:          "add(1, 2; let y = 3;",
+			expectedErrors: 1,
+			expected:       "(bad) (let y 3)",
+		},
+		{
+			input:          "if (x) { let = 1; y } let z = 2;",
+			expectedErrors: 1,
+			expected:       "(if x (block (bad) y)) (let z 2)",
+		},
+		{
+			input:          "let a = fn(x { x } let b = 2;",
+			expectedErrors: 1,
+			expected:       "(let a (bad)) (let b 2)",
+		},
+		{
+			input:          "return 1 2 3 return 4;",
+			expectedErrors: 1,
+			expected:       "(bad) (return 4)",
+		},
+		{
+			input:          "x @ y; z",
+			expectedErrors: 1,
+			expected:       "x z",
+		},
+		{
+			input:          "while (x) { let = 1; break } y",
+			expectedErrors: 1,
+			expected:       "(while x (block (bad) (break))) y",
+		},
+		{
+			input:          "fn(x) { x + }; let y = 2;",
+			expectedErrors: 1,
+			expected:       "(fn (x) (block (+ x (bad)))) (let y 2)",
+		},
+		{
+			input:          "if (x) { 1 + }",
+			expectedErrors: 1,
+			expected:       "(if x (block (+ 1 (bad))))",
+		},
+		{
+			input:          "fn(x) { let y = }; let z = 1;",
+			expectedErrors: 1,
+			expected:       "(fn (x) (block (let y (bad)))) (let z 1)",
+		},
+		{
+			input:          "fn() { let x = fn() { 1 } 2; 3 }",
+			expectedErrors: 1,
+			expected:       "(fn () (block (bad) 3))",
+		},
+		{
+			input:          "a[",
+			expectedErrors: 1,
+			expected:       "(bad)",
+		},
+		{
+			input:          "[1,,2]; x",
+			expectedErrors: 1,
+			expected:       "(bad) x",
+		},
+		{
+			input:          "let h = { x + }; x",
+			expectedErrors: 1,
+			expected:       "(let h (bad)) x",
+		},
+		{
+			input:          "f(1 + ); x",
+			expectedErrors: 1,
+			expected:       "(bad) x",
+		},
+		{
+			input:          "if (x + ) { 1 }; x",
+			expectedErrors: 1,
+			expected:       "(bad) x",
+		},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		if len(p.Errors()) != tc.expectedErrors {
+			t.Errorf("%s: expected %d errors. got: %d instead: %s", tc.input, tc.expectedErrors, len(p.Errors()), p.Errors())
+		}
+
+		output := ast.SExpr(program)
+		if output != tc.expected {
+			t.Errorf("%s: expected %s, got %s instead", tc.input, tc.expected, output)
+		}
+	}
+}
+
+func TestParseErrorLimit(t *testing.T) {
+	input := strings.Repeat("let = 1;\n", 2*MaxErrors)
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != MaxErrors {
+		t.Fatalf("expected %d errors. got: %d instead", MaxErrors, len(p.Errors()))
+	}
+
+	if len(program.Statements) != MaxErrors {
+		t.Errorf("program.Statements expected to contain %d statements. got: %d instead", MaxErrors, len(program.Statements))
+	}
+
+	for i, stmt := range program.Statements {
+		if _, ok := stmt.(*ast.BadStatement); !ok {
+			t.Errorf("program.Statements[%d] is not *ast.BadStatement. got: %T instead", i, stmt)
+		}
+	}
+}
+
+func TestParseReadError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("let x = 1;\n"), iotest.ErrReader(errors.New("disk failure")))
+
+	p := New(lexer.NewReader(r))
+	program := p.ParseProgram()
+
+	if len(program.Statements) != 1 {
+		t.Errorf("program.Statements expected to contain 1 statement. got: %d instead", len(program.Statements))
+	}
+
+	errs := p.Errors()
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error. got: %d instead: %s", len(errs), errs)
+	}
+
+	if errs[0].Kind != ReadError {
+		t.Errorf("errs[0].Kind is not %s. got: %s instead", ReadError, errs[0].Kind)
+	}
+
+	if errs[0].Msg != "read error: disk failure" {
+		t.Errorf("errs[0].Msg is not %q. got: %q instead", "read error: disk failure", errs[0].Msg)
+	}
+
+	if errs[0].Pos.Line != 2 || errs[0].Pos.Column != 1 {
+		t.Errorf("errs[0].Pos is not 2:1. got: %s instead", errs[0].Pos)
+	}
+}
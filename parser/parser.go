@@ -3,32 +3,58 @@ package parser
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/mycok/monkey_interpreter/ast"
 	"github.com/mycok/monkey_interpreter/lexer"
 	"github.com/mycok/monkey_interpreter/token"
 )
 
+// MaxErrors is the maximum number of errors reported by a Parser. Parsing stops once the
+// limit is reached since later errors are rarely useful.
+const MaxErrors = 10
+
 const (
 	_             int = iota
 	LOWEST            // Lowest rank after _
+	ASSIGN            // = OR += OR -= OR *= OR /=
+	LOGICAL_OR        // ||
+	LOGICAL_AND       // &&
 	EQUALS            // ==
-	LESSORGREATER     // > OR <
-	SUM               // +
-	PRODUCT           // *
-	PREFIX            // -X OR !X OR +X
+	LESSORGREATER     // > OR < OR >= OR <=
+	SUM               // + OR - OR | OR ^
+	PRODUCT           // * OR / OR % OR << OR >> OR &
+	PREFIX            // -X OR !X OR +X OR ~X
 	CALL              // fn() OR myFunction(x)
+	INDEX             // array[index] OR hash[key]
 )
 
 var precedences = map[token.TokenType]int{
-	token.EQ:       EQUALS,
-	token.NOTEQ:    EQUALS,
-	token.LT:       LESSORGREATER,
-	token.GT:       LESSORGREATER,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.ASTERISK: PRODUCT,
+	token.ASSIGN:          ASSIGN,
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
+	token.OR:              LOGICAL_OR,
+	token.AND:             LOGICAL_AND,
+	token.EQ:              EQUALS,
+	token.NOTEQ:           EQUALS,
+	token.LT:              LESSORGREATER,
+	token.GT:              LESSORGREATER,
+	token.LTE:             LESSORGREATER,
+	token.GTE:             LESSORGREATER,
+	token.PLUS:            SUM,
+	token.MINUS:           SUM,
+	token.BITOR:           SUM,
+	token.BITXOR:          SUM,
+	token.SLASH:           PRODUCT,
+	token.ASTERISK:        PRODUCT,
+	token.MODULO:          PRODUCT,
+	token.SHL:             PRODUCT,
+	token.SHR:             PRODUCT,
+	token.BITAND:          PRODUCT,
+	token.LPAREN:          CALL,
+	token.LBRACKET:        INDEX,
 }
 
 type (
@@ -41,24 +67,50 @@ type Parser struct {
 	l              *lexer.Lexer
 	currToken      token.Token
 	peekToken      token.Token
-	errors         []string
+	errors         ErrorList
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
+
+	// loopDepth is the number of loops enclosing the current token within the
+	// current function body. break and continue are only valid when it is positive.
+	loopDepth int
+
+	// blockDepth is the number of block statements enclosing the current token.
+	blockDepth int
+
+	// synced is the number of errors that had been reported when the parser last
+	// synchronized. Errors beyond it have not been recovered from yet.
+	synced int
+
+	// blockClosed is set when synchronize stops at the brace that closes the current
+	// block. parseBlockStatement must not advance past it.
+	blockClosed bool
 }
 
 // New returns an initialized instance of a Parser.
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		l:      l,
-		errors: []string{},
+		errors: ErrorList{},
 	}
 
 	p.prefixParseFns = make(map[token.TokenType]prefixParseFn)
 	p.registerPrefix(token.IDENT, p.parseIdentifier)
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
+	p.registerPrefix(token.FLOAT, p.parseFloatLiteral)
+	p.registerPrefix(token.STRING, p.parseStringLiteral)
+	p.registerPrefix(token.ILLEGAL, p.parseIllegal)
 	p.registerPrefix(token.MINUS, p.parsePrefixExpression)
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
 	p.registerPrefix(token.PLUS, p.parsePrefixExpression)
+	p.registerPrefix(token.BITNOT, p.parsePrefixExpression)
+	p.registerPrefix(token.TRUE, p.parseBoolean)
+	p.registerPrefix(token.FALSE, p.parseBoolean)
+	p.registerPrefix(token.LPAREN, p.parseGroupedExpression)
+	p.registerPrefix(token.IF, p.parseIfExpression)
+	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
+	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
+	p.registerPrefix(token.LBRACE, p.parseHashLiteral)
 
 	p.infixParseFns = make(map[token.TokenType]infixParseFn)
 	p.registerInfix(token.PLUS, p.parseInfixExpression)
@@ -69,6 +121,23 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.NOTEQ, p.parseInfixExpression)
 	p.registerInfix(token.GT, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
+	p.registerInfix(token.GTE, p.parseInfixExpression)
+	p.registerInfix(token.LTE, p.parseInfixExpression)
+	p.registerInfix(token.MODULO, p.parseInfixExpression)
+	p.registerInfix(token.AND, p.parseInfixExpression)
+	p.registerInfix(token.OR, p.parseInfixExpression)
+	p.registerInfix(token.BITAND, p.parseInfixExpression)
+	p.registerInfix(token.BITOR, p.parseInfixExpression)
+	p.registerInfix(token.BITXOR, p.parseInfixExpression)
+	p.registerInfix(token.SHL, p.parseInfixExpression)
+	p.registerInfix(token.SHR, p.parseInfixExpression)
+	p.registerInfix(token.LPAREN, p.parseCallExpression)
+	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
+	p.registerInfix(token.ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.PLUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.MINUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.ASTERISK_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.SLASH_ASSIGN, p.parseAssignExpression)
 
 	// Read two tokens so that both currToken & peekToken are set.
 	p.nextToken()
@@ -85,14 +154,40 @@ func (p *Parser) registerInfix(tokenType token.TokenType, fn infixParseFn) {
 	p.infixParseFns[tokenType] = fn
 }
 
-// Errors returns p.errors slice of string errors.
-func (p *Parser) Errors() []string {
+// Errors returns the list of errors encountered while parsing. After ParseProgram the
+// list is sorted by position and contains every error only once.
+func (p *Parser) Errors() ErrorList {
 	return p.errors
 }
 
 func (p *Parser) nextToken() {
 	p.currToken = p.peekToken
 	p.peekToken = p.l.NextToken()
+
+	// Comments are only meaningful to tools such as formatters and are skipped when the
+	// lexer is configured to retain them.
+	for p.peekToken.Type == token.COMMENT {
+		p.peekToken = p.l.NextToken()
+	}
+
+	// Every ILLEGAL token has a matching lexer error that explains why the token is
+	// illegal. Report it as soon as the token is read so that it is reported exactly once.
+	if p.peekToken.Type == token.ILLEGAL {
+		msg := fmt.Sprintf("illegal token %q", p.peekToken.Literal)
+		if errs := p.l.Errors(); len(errs) > 0 {
+			msg = errs[len(errs)-1].Msg
+		}
+
+		p.addError(IllegalToken, "", p.peekToken, msg)
+	}
+
+	// A read error ends the input early. It is reported with the first EOF token so that
+	// a truncated program is not mistaken for a complete one.
+	if p.peekTokenIs(token.EOF) && !p.curTokenIs(token.EOF) {
+		if err := p.l.ReadError(); err != nil {
+			p.addError(ReadError, "", p.peekToken, err.Msg)
+		}
+	}
 }
 
 // ParseProgram return an instance of *ast.Program as the root node with all the
@@ -101,30 +196,111 @@ func (p *Parser) ParseProgram() *ast.Program {
 	program := &ast.Program{}
 	program.Statements = []ast.Statement{}
 
-	for !p.curTokenIs(token.EOF) {
-		stmt := p.parseStatement()
-		if stmt != nil {
-			program.Statements = append(program.Statements, stmt)
-		}
+	for !p.curTokenIs(token.EOF) && len(p.errors) < MaxErrors {
+		program.Statements = append(program.Statements, p.parseStatement())
 
 		p.nextToken()
 	}
 
+	// The error of an ILLEGAL token is reported as soon as the token is read, which may be
+	// before the errors of the tokens in front of it.
+	p.errors.Deduplicate()
+
 	return program
 }
 
+// parseStatement parses a single statement. When the statement contains an error that
+// has not been recovered from yet, the parser skips to the end of the statement so that
+// the error does not cascade into the following statements. A statement that could not
+// be parsed at all is replaced by an *ast.BadStatement.
 func (p *Parser) parseStatement() ast.Statement {
+	start := p.currToken
+
+	var stmt ast.Statement
+
 	switch p.currToken.Type {
 	case token.LET:
-		return p.parseLetStatement()
+		stmt = p.parseLetStatement()
 	case token.RETURN:
-		return p.parseReturnStatement()
+		stmt = p.parseReturnStatement()
+	case token.WHILE:
+		stmt = p.parseWhileStatement()
+	case token.FOR:
+		stmt = p.parseForStatement()
+	case token.BREAK:
+		stmt = p.parseBreakStatement()
+	case token.CONTINUE:
+		stmt = p.parseContinueStatement()
+	default:
+		stmt = p.parseExpressionStatement()
+	}
+
+	if p.hasUnrecoveredErrors() {
+		p.synchronize()
+	}
+
+	if stmt == nil {
+		return &ast.BadStatement{Token: start, To: p.currToken.End()}
+	}
+
+	return stmt
+}
+
+// hasUnrecoveredErrors reports whether errors have been reported since the parser last
+// synchronized.
+func (p *Parser) hasUnrecoveredErrors() bool {
+	return len(p.errors) > p.synced
+}
+
+// synchronize skips tokens until p.currToken is the semicolon that ends the current
+// statement or p.peekToken starts a new statement or closes the enclosing block. Braces
+// opened while skipping are skipped together with their matching closing brace. A
+// closing brace of the enclosing block that caused the error is not skipped.
+func (p *Parser) synchronize() {
+	// The brace that closes the current block may itself be the offending token, as in
+	// `{ x + }`. It is left for parseBlockStatement.
+	if p.blockDepth > 0 && p.curTokenIs(token.RBRACE) && p.errors[len(p.errors)-1].Pos == p.currToken.Pos {
+		p.blockClosed = true
+		p.synced = len(p.errors)
+
+		return
+	}
+
+	depth := 0
+
+	for !p.curTokenIs(token.EOF) {
+		if p.curTokenIs(token.LBRACE) {
+			depth++
+		}
+
+		if depth == 0 && (p.curTokenIs(token.SEMICOLON) || p.atStatementBoundary()) {
+			break
+		}
+
+		if depth > 0 && p.peekTokenIs(token.RBRACE) {
+			depth--
+		}
+
+		p.nextToken()
+	}
+
+	p.synced = len(p.errors)
+}
+
+// atStatementBoundary reports whether p.peekToken starts a new statement or closes the
+// block that is currently being parsed.
+func (p *Parser) atStatementBoundary() bool {
+	switch p.peekToken.Type {
+	case token.EOF, token.LET, token.RETURN, token.WHILE, token.FOR, token.BREAK, token.CONTINUE:
+		return true
+	case token.RBRACE:
+		return p.blockDepth > 0
 	default:
-		return p.parseExpressionStatement()
+		return false
 	}
 }
 
-func (p *Parser) parseLetStatement() *ast.LetStatement {
+func (p *Parser) parseLetStatement() ast.Statement {
 	// Create a Statement instance with the current p.currToken which in this case
 	// should be a token of LET type.
 	stmt := &ast.LetStatement{Token: p.currToken}
@@ -142,39 +318,187 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 		return nil
 	}
 
-	// TODO: Add expression parsing functionality.
+	// Move past the ASSIGN token so that p.currToken points to the first token of the
+	// value expression.
+	p.nextToken()
 
-	// Loop through the expression until a semicolon token is reached which in this
-	// case means the end of the statement.
-	for !p.curTokenIs(token.SEMICOLON) {
-		p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+
+	// A let statement must be terminated by a semicolon unless it is the last statement
+	// of a block or of the input. A value that contains an error is not checked since
+	// parseStatement skips to the end of the statement anyway.
+	if p.peekTokenIs(token.RBRACE) || p.peekTokenIs(token.EOF) || p.hasUnrecoveredErrors() {
+		return stmt
+	}
+
+	if !p.peekExpectedType(token.SEMICOLON) {
+		return nil
 	}
 
 	return stmt
 }
 
-func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
+func (p *Parser) parseReturnStatement() ast.Statement {
 	// Create a Statement instance with the current p.currToken which in this case
-	// should be a token of LET type.
+	// should be a token of RETURN type.
 	stmt := &ast.ReturnStatement{Token: p.currToken}
+
+	// A bare return such as "return;", "{ return }" or a return at the end of the input
+	// has no return value.
+	if p.peekTokenIs(token.SEMICOLON) || p.peekTokenIs(token.RBRACE) || p.peekTokenIs(token.EOF) {
+		if p.peekTokenIs(token.SEMICOLON) {
+			p.nextToken()
+		}
+
+		return stmt
+	}
+
+	p.nextToken()
+
+	stmt.ReturnValue = p.parseExpression(LOWEST)
+
+	// A return statement must be terminated by a semicolon unless it is the last
+	// statement of a block or of the input. A value that contains an error is not
+	// checked since parseStatement skips to the end of the statement anyway.
+	if p.peekTokenIs(token.RBRACE) || p.peekTokenIs(token.EOF) || p.hasUnrecoveredErrors() {
+		return stmt
+	}
+
+	if !p.peekExpectedType(token.SEMICOLON) {
+		return nil
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseWhileStatement() ast.Statement {
+	stmt := &ast.WhileStatement{Token: p.currToken}
+
+	if !p.peekExpectedType(token.LPAREN) {
+		return nil
+	}
+
+	p.nextToken()
+
+	// The condition is not part of the loop, so break and continue are only valid in
+	// the body.
+	stmt.Condition = p.parseExpression(LOWEST)
+
+	if !p.peekExpectedClosing(token.RPAREN) {
+		return nil
+	}
+
+	if !p.peekExpectedType(token.LBRACE) {
+		return nil
+	}
+
+	p.loopDepth++
+	stmt.Body = p.parseBlockStatement()
+	p.loopDepth--
+
+	if stmt.Body == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseForStatement() ast.Statement {
+	stmt := &ast.ForStatement{Token: p.currToken}
+
+	if !p.peekExpectedType(token.LPAREN) {
+		return nil
+	}
+
+	if !p.peekExpectedType(token.IDENT) {
+		return nil
+	}
+
+	stmt.Variable = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+
+	if !p.peekExpectedType(token.IN) {
+		return nil
+	}
+
 	p.nextToken()
 
-	// TODO: Add expression parsing functionality.
+	stmt.Iterable = p.parseExpression(LOWEST)
+
+	if !p.peekExpectedClosing(token.RPAREN) {
+		return nil
+	}
+
+	if !p.peekExpectedType(token.LBRACE) {
+		return nil
+	}
+
+	p.loopDepth++
+	stmt.Body = p.parseBlockStatement()
+	p.loopDepth--
+
+	if stmt.Body == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseBreakStatement() ast.Statement {
+	stmt := &ast.BreakStatement{Token: p.currToken}
+
+	if !p.checkInsideLoop() {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseContinueStatement() ast.Statement {
+	stmt := &ast.ContinueStatement{Token: p.currToken}
 
-	// Loop through the expression until a semicolon token is reached which in this
-	// case means the end of the statement.
-	for !p.curTokenIs(token.SEMICOLON) {
+	if !p.checkInsideLoop() {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
 
 	return stmt
 }
 
-func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
+// checkInsideLoop reports whether p.currToken is enclosed by a loop and records an error
+// if it is not.
+func (p *Parser) checkInsideLoop() bool {
+	if p.loopDepth > 0 {
+		return true
+	}
+
+	msg := fmt.Sprintf("%s statement outside of a loop", p.currToken.Literal)
+	p.addError(UnexpectedToken, "", p.currToken, msg)
+
+	return false
+}
+
+func (p *Parser) parseExpressionStatement() ast.Statement {
 	stmt := &ast.ExpressionStatement{Token: p.currToken}
 	stmt.Expression = p.parseExpression(LOWEST)
 
-	if p.peekTokenIs(token.SEMICOLON) {
+	// An expression that contains an error may end at the brace that closes the
+	// enclosing block, in which case the semicolon after it belongs to the outer statement.
+	if p.peekTokenIs(token.SEMICOLON) && !p.hasUnrecoveredErrors() {
 		// Calling p.nextToken at this point returns a semicolon token. This means that
 		// when the ParseProgram loop calls p.nextToken after this, "" / EOF token type which represents
 		// EOF or end of file will be returned as p.currToken. This EOF token will cause the ParseProgram method loop to terminate.
@@ -186,14 +510,19 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 
 func (p *Parser) parseExpression(precedence int) ast.Expression {
 	// Perform a parse function lookup from p.prefixParseFns map.
+	start := p.currToken
+
 	parseFn := p.prefixParseFns[p.currToken.Type]
 	if parseFn == nil {
 		p.noPrefixParseFnError(p.currToken.Type)
 
-		return nil
+		return &ast.BadExpression{Token: start, To: p.currToken.End()}
 	}
 
 	expression := parseFn()
+	if expression == nil {
+		return &ast.BadExpression{Token: start, To: p.currToken.End()}
+	}
 
 	// Check if the next token is not a semicolon and also if the next token precedence
 	// is higher than the precedence argument.
@@ -206,6 +535,9 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		p.nextToken()
 
 		expression = parseFn(expression)
+		if expression == nil {
+			return &ast.BadExpression{Token: start, To: p.currToken.End()}
+		}
 	}
 
 	return expression
@@ -219,10 +551,11 @@ func (p *Parser) parseIdentifier() ast.Expression {
 func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{Token: p.currToken}
 
-	intValue, err := strconv.ParseInt(p.currToken.Literal, 0, 64)
+	// Digit separators are validated by the lexer and carry no meaning.
+	intValue, err := strconv.ParseInt(strings.ReplaceAll(p.currToken.Literal, "_", ""), 0, 64)
 	if err != nil {
 		msg := fmt.Sprintf("could not parse %q as integer", p.currToken.Literal)
-		p.errors = append(p.errors, msg)
+		p.addError(InvalidLiteral, "", p.currToken, msg)
 	}
 
 	lit.Value = intValue
@@ -230,6 +563,159 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseBoolean() ast.Expression {
+	return &ast.Boolean{Token: p.currToken, Value: p.curTokenIs(token.TRUE)}
+}
+
+func (p *Parser) parseGroupedExpression() ast.Expression {
+	exp := &ast.ParenExpression{Token: p.currToken}
+
+	p.nextToken()
+
+	// Parsing the inner expression with the LOWEST precedence makes sure that the
+	// grouped expression binds tighter than any surrounding operator.
+	exp.Expression = p.parseExpression(LOWEST)
+
+	if !p.peekExpectedClosing(token.RPAREN) {
+		return nil
+	}
+
+	exp.Rparen = p.currToken.Pos
+
+	return exp
+}
+
+func (p *Parser) parseIfExpression() ast.Expression {
+	exp := &ast.IfExpression{Token: p.currToken}
+
+	exp.Condition, exp.Consequence = p.parseConditionalBranch()
+	if exp.Consequence == nil {
+		return nil
+	}
+
+	for p.peekTokenIs(token.ELSEIF) || p.peekTokenIs(token.ELSE) {
+		p.nextToken()
+
+		// An else keyword followed by an if keyword on a new line is treated the same
+		// way as the ELSEIF token produced by the lexer.
+		if p.curTokenIs(token.ELSE) && p.peekTokenIs(token.IF) {
+			p.nextToken()
+		}
+
+		if p.curTokenIs(token.ELSE) {
+			if !p.peekExpectedType(token.LBRACE) {
+				return nil
+			}
+
+			exp.Alternative = p.parseBlockStatement()
+			if exp.Alternative == nil {
+				return nil
+			}
+
+			break
+		}
+
+		branch := &ast.ElseIfBranch{Token: p.currToken}
+
+		branch.Condition, branch.Consequence = p.parseConditionalBranch()
+		if branch.Consequence == nil {
+			return nil
+		}
+
+		exp.ElseIfs = append(exp.ElseIfs, branch)
+	}
+
+	return exp
+}
+
+// parseConditionalBranch parses the "(condition) { consequence }" part shared by if and
+// elseif branches. p.currToken is expected to be the if / elseif keyword.
+func (p *Parser) parseConditionalBranch() (ast.Expression, *ast.BlockStatement) {
+	if !p.peekExpectedType(token.LPAREN) {
+		return nil, nil
+	}
+
+	p.nextToken()
+
+	condition := p.parseExpression(LOWEST)
+
+	if !p.peekExpectedClosing(token.RPAREN) {
+		return nil, nil
+	}
+
+	if !p.peekExpectedType(token.LBRACE) {
+		return nil, nil
+	}
+
+	return condition, p.parseBlockStatement()
+}
+
+func (p *Parser) parseBlockStatement() *ast.BlockStatement {
+	block := &ast.BlockStatement{Token: p.currToken}
+	block.Statements = []ast.Statement{}
+
+	p.blockDepth++
+	defer func() { p.blockDepth-- }()
+
+	p.nextToken()
+
+	for !p.curTokenIs(token.RBRACE) {
+		if p.curTokenIs(token.EOF) {
+			msg := fmt.Sprintf("expected %s to close the block, got: %s instead", token.RBRACE, token.EOF)
+			p.addError(UnexpectedToken, token.RBRACE, p.currToken, msg)
+
+			return nil
+		}
+
+		block.Statements = append(block.Statements, p.parseStatement())
+
+		if p.blockClosed {
+			p.blockClosed = false
+
+			break
+		}
+
+		p.nextToken()
+	}
+
+	block.Rbrace = p.currToken.Pos
+
+	return block
+}
+
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Token: p.currToken}
+
+	floatValue, err := strconv.ParseFloat(strings.ReplaceAll(p.currToken.Literal, "_", ""), 64)
+	if err != nil {
+		msg := fmt.Sprintf("could not parse %q as float", p.currToken.Literal)
+		p.addError(InvalidLiteral, "", p.currToken, msg)
+	}
+
+	lit.Value = floatValue
+
+	return lit
+}
+
+func (p *Parser) parseStringLiteral() ast.Expression {
+	lit := &ast.StringLiteral{Token: p.currToken}
+
+	value, err := lexer.Unquote(p.currToken.Literal)
+	if err != nil {
+		p.addError(InvalidLiteral, "", p.currToken, err.Error())
+	}
+
+	lit.Value = value
+
+	return lit
+}
+
+// parseIllegal replaces an ILLEGAL token with a placeholder expression. The error
+// describing the token has already been reported by p.nextToken.
+func (p *Parser) parseIllegal() ast.Expression {
+	return &ast.BadExpression{Token: p.currToken, To: p.currToken.End()}
+}
+
 func (p *Parser) parsePrefixExpression() ast.Expression {
 	exp := &ast.PrefixExpression{
 		Token:    p.currToken,
@@ -259,11 +745,195 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	return exp
 }
 
+// parseAssignExpression parses an assignment to an identifier or an index expression.
+// Assignments are right associative, so (a = b = c) is parsed as (a = (b = c)).
+func (p *Parser) parseAssignExpression(target ast.Expression) ast.Expression {
+	exp := &ast.AssignExpression{
+		Token:    p.currToken, // p.currToken is the assignment operator.
+		Target:   target,
+		Operator: p.currToken.Literal,
+	}
+
+	switch ast.Unparen(target).(type) {
+	case *ast.Identifier, *ast.IndexExpression:
+	case *ast.BadExpression:
+		// The error that produced the bad target has already been reported.
+		return nil
+	default:
+		msg := fmt.Sprintf("cannot assign to %s", target.String())
+		p.addError(InvalidAssignment, "", p.currToken, msg)
+
+		return nil
+	}
+
+	p.nextToken()
+
+	exp.Value = p.parseExpression(ASSIGN - 1)
+
+	return exp
+}
+
+func (p *Parser) parseFunctionLiteral() ast.Expression {
+	lit := &ast.FunctionLiteral{Token: p.currToken}
+
+	if !p.peekExpectedType(token.LPAREN) {
+		return nil
+	}
+
+	lit.Parameters = p.parseFunctionParameters()
+	if lit.Parameters == nil {
+		return nil
+	}
+
+	if !p.peekExpectedType(token.LBRACE) {
+		return nil
+	}
+
+	// Loops do not extend into function bodies, so a break inside a function that is
+	// defined within a loop is still an error.
+	loopDepth := p.loopDepth
+	p.loopDepth = 0
+	lit.Body = p.parseBlockStatement()
+	p.loopDepth = loopDepth
+
+	if lit.Body == nil {
+		return nil
+	}
+
+	return lit
+}
+
+// parseFunctionParameters parses a comma separated list of identifiers terminated by a
+// ")" token. A trailing comma is allowed. p.currToken is expected to be the "(" token.
+func (p *Parser) parseFunctionParameters() []*ast.Identifier {
+	identifiers := []*ast.Identifier{}
+
+	for !p.peekTokenIs(token.RPAREN) {
+		if !p.peekExpectedType(token.IDENT) {
+			return nil
+		}
+
+		identifiers = append(identifiers, &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal})
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	if !p.peekExpectedType(token.RPAREN) {
+		return nil
+	}
+
+	return identifiers
+}
+
+func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
+	exp := &ast.CallExpression{Token: p.currToken, Function: function}
+
+	exp.Arguments = p.parseExpressionList(token.RPAREN)
+	if exp.Arguments == nil {
+		return nil
+	}
+
+	exp.Rparen = p.currToken.Pos
+
+	return exp
+}
+
+func (p *Parser) parseArrayLiteral() ast.Expression {
+	array := &ast.ArrayLiteral{Token: p.currToken}
+
+	array.Elements = p.parseExpressionList(token.RBRACKET)
+	if array.Elements == nil {
+		return nil
+	}
+
+	array.Rbracket = p.currToken.Pos
+
+	return array
+}
+
+func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
+	exp := &ast.IndexExpression{Token: p.currToken, Left: left}
+
+	p.nextToken()
+	exp.Index = p.parseExpression(LOWEST)
+
+	if !p.peekExpectedClosing(token.RBRACKET) {
+		return nil
+	}
+
+	exp.Rbracket = p.currToken.Pos
+
+	return exp
+}
+
+// parseHashLiteral parses a comma separated list of key: value pairs terminated by a
+// "}" token. A trailing comma is allowed. p.currToken is expected to be the "{" token.
+func (p *Parser) parseHashLiteral() ast.Expression {
+	hash := &ast.HashLiteral{Token: p.currToken, Pairs: []*ast.HashPair{}}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		key := p.parseExpression(LOWEST)
+
+		if !p.peekExpectedClosing(token.COLON) {
+			return nil
+		}
+
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+
+		hash.Pairs = append(hash.Pairs, &ast.HashPair{Key: key, Value: value})
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	if !p.peekExpectedClosing(token.RBRACE) {
+		return nil
+	}
+
+	hash.Rbrace = p.currToken.Pos
+
+	return hash
+}
+
+// parseExpressionList parses a comma separated list of expressions terminated by a
+// token of the end type. A trailing comma is allowed. p.currToken is expected to be
+// the token that opens the list.
+func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
+	list := []ast.Expression{}
+
+	for !p.peekTokenIs(end) {
+		p.nextToken()
+
+		list = append(list, p.parseExpression(LOWEST))
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	if !p.peekExpectedClosing(end) {
+		return nil
+	}
+
+	return list
+}
+
 // End*****token type parse methods*****
 
 func (p *Parser) noPrefixParseFnError(t token.TokenType) {
 	msg := fmt.Sprintf("no prefix parse function for %s found", t)
-	p.errors = append(p.errors, msg)
+	p.addError(NoPrefixParseFn, "", p.currToken, msg)
 }
 
 func (p *Parser) curTokenIs(t token.TokenType) bool {
@@ -285,9 +955,39 @@ func (p *Parser) peekExpectedType(t token.TokenType) bool {
 	return false
 }
 
+// peekExpectedClosing is like peekExpectedType for a token that closes or separates the
+// parts of an expression. A missing token is not reported after an error that has not
+// been recovered from yet since that error is most likely the reason it is missing.
+func (p *Parser) peekExpectedClosing(t token.TokenType) bool {
+	if p.hasUnrecoveredErrors() && !p.peekTokenIs(t) {
+		return false
+	}
+
+	return p.peekExpectedType(t)
+}
+
 func (p *Parser) peekError(t token.TokenType) {
 	msg := fmt.Sprintf("expected next token to be %s, got: %s instead", t, p.peekToken.Type)
-	p.errors = append(p.errors, msg)
+	p.addError(UnexpectedToken, t, p.peekToken, msg)
+
+	if t == token.SEMICOLON {
+		p.errors[len(p.errors)-1].Hint = "did you forget a semicolon?"
+	}
+}
+
+func (p *Parser) addError(kind ErrorKind, expected token.TokenType, tok token.Token, msg string) {
+	if len(p.errors) >= MaxErrors {
+		return
+	}
+
+	p.errors.Add(&ParseError{
+		Kind:     kind,
+		Expected: expected,
+		Got:      tok.Type,
+		Pos:      tok.Pos,
+		End:      tok.End(),
+		Msg:      msg,
+	})
 }
 
 func (p *Parser) peekPrecedence() int {
@@ -6,13 +6,48 @@ import (
 
 	"github.com/mycok/monkey_interpreter/ast"
 	"github.com/mycok/monkey_interpreter/lexer"
+	"github.com/mycok/monkey_interpreter/token"
 )
 
 func TestParseLetStatements(t *testing.T) {
+	tests := []struct {
+		input              string
+		expectedIdentifier string
+		expectedValue      interface{}
+	}{
+		{"let x = 5;", "x", 5},
+		{"let y = 10;", "y", 10},
+		{"let foobar = y;", "foobar", "y"},
+		{"let x = 5", "x", 5},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+		}
+
+		stmt := program.Statements[0]
+		if !testLetStatement(t, stmt, tc.expectedIdentifier) {
+			return
+		}
+
+		value := stmt.(*ast.LetStatement).Value
+		if !testLiteralExpression(t, value, tc.expectedValue) {
+			return
+		}
+	}
+}
+
+func TestParseLetStatementExpressions(t *testing.T) {
 	input := `
-	let x = 5;
-	let y = 10;
-	let foobar = 838383;
+	let x = 5 * y;
+	let y = -a + b
 	`
 
 	l := lexer.New(input)
@@ -21,16 +56,16 @@ func TestParseLetStatements(t *testing.T) {
 
 	checkParserErrors(t, p)
 
-	if len(program.Statements) != 3 {
-		t.Fatalf("program.Statements expected to contain 3 statements. but got: %d instead", len(program.Statements))
+	if len(program.Statements) != 2 {
+		t.Fatalf("program.Statements expected to contain 2 statements. but got: %d instead", len(program.Statements))
 	}
 
 	tests := []struct {
 		expectedIdentifier string
+		expectedValue      string
 	}{
-		{"x"},
-		{"y"},
-		{"foobar"},
+		{"x", "(5 * y)"},
+		{"y", "((-a) + b)"},
 	}
 
 	for i, tc := range tests {
@@ -39,15 +74,104 @@ func TestParseLetStatements(t *testing.T) {
 			return
 		}
 
+		value := stmt.(*ast.LetStatement).Value
+		if value == nil {
+			t.Fatalf("letStmt.Value is nil")
+		}
+
+		if value.String() != tc.expectedValue {
+			t.Errorf("letStmt.Value.String() is not %q. got: %q instead", tc.expectedValue, value.String())
+		}
+	}
+
+	letStmt := program.Statements[0].(*ast.LetStatement)
+	if !testInfixExpression(t, letStmt.Value, 5, "*", "y") {
+		return
+	}
+}
+
+func TestParseUnterminatedLetStatements(t *testing.T) {
+	tests := []string{
+		"let x = 5 let y = 6;",
+		"let x = 5 6",
+		"let x = 5\nx",
+	}
+
+	for _, input := range tests {
+		l := lexer.New(input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("expected 1 parser error for %q. got: %d instead", input, len(errors))
+		}
+
+		if errors[0].Expected != token.SEMICOLON {
+			t.Errorf("errors[0].Expected is not %q. got: %q instead", token.SEMICOLON, errors[0].Expected)
+		}
 	}
+
+	// The semicolon may be omitted before the closing brace of a block.
+	l := lexer.New("fn() { let x = 5 }")
+	p := New(l)
+	p.ParseProgram()
+
+	checkParserErrors(t, p)
 }
 
 func TestParseReturnStatements(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedValue interface{}
+	}{
+		{"return 5;", 5},
+		{"return 15;", 15},
+		{"return foobar;", "foobar"},
+		{"return;", nil},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+		}
+
+		returnStmt, ok := program.Statements[0].(*ast.ReturnStatement)
+		if !ok {
+			t.Fatalf("stmt is not of a valid *ast.ReturnStatement type. got: %T instead", program.Statements[0])
+		}
+
+		if returnStmt.TokenLiteral() != "return" {
+			t.Errorf("returnStmt.TokenLiteral is not 'return', got: %q instead", returnStmt.TokenLiteral())
+		}
+
+		if tc.expectedValue == nil {
+			if returnStmt.ReturnValue != nil {
+				t.Errorf("returnStmt.ReturnValue is not nil. got: %s instead", returnStmt.ReturnValue)
+			}
+
+			continue
+		}
+
+		if !testLiteralExpression(t, returnStmt.ReturnValue, tc.expectedValue) {
+			return
+		}
+	}
+}
+
+func TestParseReturnStatementExpressions(t *testing.T) {
 	input := `
-	return 5;
-	return 15;
+	return 5 * y;
+	return -a + b;
 	return add(5, 2);
 	`
+
 	l := lexer.New(input)
 	p := New(l)
 	program := p.ParseProgram()
@@ -58,16 +182,73 @@ func TestParseReturnStatements(t *testing.T) {
 		t.Fatalf("program.Statements expected to contain 3 statements. but got: %d instead", len(program.Statements))
 	}
 
-	for _, stmt := range program.Statements {
+	expected := []string{"(5 * y)", "((-a) + b)", "add(5, 2)"}
+
+	for i, stmt := range program.Statements {
 		returnStmt, ok := stmt.(*ast.ReturnStatement)
+		if !ok {
+			t.Fatalf("stmt is not of a valid *ast.ReturnStatement type. got: %T instead", stmt)
+		}
+
+		if returnStmt.ReturnValue.String() != expected[i] {
+			t.Errorf("returnStmt.ReturnValue.String() is not %q. got: %q instead", expected[i], returnStmt.ReturnValue.String())
+		}
+	}
+}
+
+func TestParseUnterminatedReturnStatements(t *testing.T) {
+	tests := []string{
+		"return 5 6",
+		"return x + y let z = 1;",
+	}
+
+	for _, input := range tests {
+		l := lexer.New(input)
+		p := New(l)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parser errors for %q. got none", input)
+		}
+	}
+}
+
+func TestParseReturnAtEndOfInput(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 1;\nreturn", ""},
+		{"let x = 1;\nreturn 5", "5"},
+		{"let x = 1;\nreturn x + y", "(x + y)"},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
 
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 2 {
+			t.Fatalf("program.Statements expected to contain 2 statements. but got: %d instead", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[1].(*ast.ReturnStatement)
 		if !ok {
-			t.Errorf("stmt is not of a valid *ast.ReturnStatement type. got: %T instead", stmt)
+			t.Fatalf("program.Statements[1] is not a valid *ast.ReturnStatement type. got: %T instead", program.Statements[1])
+		}
+
+		if tc.expected == "" {
+			if stmt.ReturnValue != nil {
+				t.Errorf("stmt.ReturnValue expected to be nil. got: %s instead", stmt.ReturnValue)
+			}
+
 			continue
 		}
 
-		if returnStmt.TokenLiteral() != "return" {
-			t.Errorf("returnStmt.TokenLiteral is not 'return', got: %T instead", returnStmt.TokenLiteral())
+		if stmt.ReturnValue == nil || stmt.ReturnValue.String() != tc.expected {
+			t.Errorf("stmt.ReturnValue expected to be %s. got: %v instead", tc.expected, stmt.ReturnValue)
 		}
 	}
 }
@@ -138,15 +319,76 @@ func TestParseIntegerLiteralExpressions(t *testing.T) {
 
 }
 
+func TestParseIntegerLiteralBases(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0xFF;", 255},
+		{"0o755;", 493},
+		{"0b1010;", 10},
+		{"1_000_000;", 1000000},
+		{"0x_FF_FF;", 65535},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+		literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("expression is not a valid *ast.IntegerLiteral type. got: %T instead", stmt.Expression)
+		}
+
+		if literal.Value != tc.expected {
+			t.Errorf("literal.Value not %d. got: %d instead", tc.expected, literal.Value)
+		}
+
+		// The original spelling is kept for round-tripping.
+		if literal.String()+";" != tc.input {
+			t.Errorf("literal.String() not %q. got: %q instead", tc.input, literal.String())
+		}
+	}
+}
+
+func TestParseIntegerLiteralWithLeadingZero(t *testing.T) {
+	for _, input := range []string{"let x = 010;", "let x = 09;", "let x = 0_7;"} {
+		l := lexer.New(input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("%s: expected 1 error. got: %d instead: %s", input, len(errors), errors)
+		}
+
+		if errors[0].Kind != IllegalToken {
+			t.Errorf("%s: errors[0].Kind is not %s. got: %s instead", input, IllegalToken, errors[0].Kind)
+		}
+
+		if errors[0].Pos.Line != 1 || errors[0].Pos.Column != 9 {
+			t.Errorf("%s: errors[0].Pos is not 1:9. got: %s instead", input, errors[0].Pos)
+		}
+	}
+}
+
 func TestParsePrefixExpressions(t *testing.T) {
 	tests := []struct {
-		input        string
-		operator     string
-		integerValue int64
+		input    string
+		operator string
+		value    interface{}
 	}{
 		{"!5;", "!", 5},
 		{"+23;", "+", 23},
+		{"~7;", "~", 7},
 		{"-15;", "-", 15},
+		{"!true;", "!", true},
+		{"!false;", "!", false},
 	}
 
 	for _, tc := range tests {
@@ -175,7 +417,7 @@ func TestParsePrefixExpressions(t *testing.T) {
 			t.Fatalf("exp.Operator is not '%s'. got: %s instead", tc.operator, exp.Operator)
 		}
 
-		if !testIntegerLiteral(t, exp.Right, tc.integerValue) {
+		if !testLiteralExpression(t, exp.Right, tc.value) {
 			return
 		}
 	}
@@ -184,9 +426,9 @@ func TestParsePrefixExpressions(t *testing.T) {
 func TestParseInfixExpressions(t *testing.T) {
 	tests := []struct {
 		input      string
-		leftValue  int64
+		leftValue  interface{}
 		operator   string
-		rightValue int64
+		rightValue interface{}
 	}{
 		{
 			input:      "2 + 1;",
@@ -242,6 +484,48 @@ func TestParseInfixExpressions(t *testing.T) {
 			operator:   "!=",
 			rightValue: 6,
 		},
+		{
+			input:      "9 <= 6;",
+			leftValue:  9,
+			operator:   "<=",
+			rightValue: 6,
+		},
+		{
+			input:      "9 >= 6;",
+			leftValue:  9,
+			operator:   ">=",
+			rightValue: 6,
+		},
+		{
+			input:      "9 % 6;",
+			leftValue:  9,
+			operator:   "%",
+			rightValue: 6,
+		},
+		{
+			input:      "true && false",
+			leftValue:  true,
+			operator:   "&&",
+			rightValue: false,
+		},
+		{
+			input:      "a || b",
+			leftValue:  "a",
+			operator:   "||",
+			rightValue: "b",
+		},
+		{
+			input:      "true == true",
+			leftValue:  true,
+			operator:   "==",
+			rightValue: true,
+		},
+		{
+			input:      "true != false",
+			leftValue:  true,
+			operator:   "!=",
+			rightValue: false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -260,20 +544,7 @@ func TestParseInfixExpressions(t *testing.T) {
 			t.Fatalf("program.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", program.Statements[0])
 		}
 
-		exp, ok := stmt.Expression.(*ast.InfixExpression)
-		if !ok {
-			t.Fatalf("expression is not a valid *ast.InfixExpression type. got: %T instead", stmt.Expression)
-		}
-
-		if !testIntegerLiteral(t, exp.Left, tc.leftValue) {
-			return
-		}
-
-		if exp.Operator != tc.operator {
-			t.Errorf("exp.operator is not '%s', got: '%s' instead", tc.operator, exp.Operator)
-		}
-
-		if !testIntegerLiteral(t, exp.Right, tc.rightValue) {
+		if !testInfixExpression(t, stmt.Expression, tc.leftValue, tc.operator, tc.rightValue) {
 			return
 		}
 	}
@@ -335,6 +606,146 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			input:    "3 + 4 * 5 == 3 * 1 + 4 * 5;",
 			expected: "((3 + (4 * 5)) == ((3 * 1) + (4 * 5)))",
 		},
+		{
+			input:    "true;",
+			expected: "true",
+		},
+		{
+			input:    "3 > 5 == false;",
+			expected: "((3 > 5) == false)",
+		},
+		{
+			input:    "3 < 5 == true;",
+			expected: "((3 < 5) == true)",
+		},
+		{
+			input:    "1 + (2 + 3) + 4;",
+			expected: "((1 + (2 + 3)) + 4)",
+		},
+		{
+			input:    "(5 + 5) * 2;",
+			expected: "((5 + 5) * 2)",
+		},
+		{
+			input:    "(1 + 2) * 3;",
+			expected: "((1 + 2) * 3)",
+		},
+		{
+			input:    "2 / (5 + 5);",
+			expected: "(2 / (5 + 5))",
+		},
+		{
+			input:    "-(5 + 5);",
+			expected: "(-(5 + 5))",
+		},
+		{
+			input:    "!(true == true);",
+			expected: "(!(true == true))",
+		},
+		{
+			input:    "!(a == b);",
+			expected: "(!(a == b))",
+		},
+		{
+			input:    "((a + b) * (c - d)) / e;",
+			expected: "(((a + b) * (c - d)) / e)",
+		},
+		{
+			input:    "a + add(b * c) + d;",
+			expected: "((a + add((b * c))) + d)",
+		},
+		{
+			input:    "add(a, b, 1, 2 * 3, 4 + 5, add(6, 7 * 8));",
+			expected: "add(a, b, 1, (2 * 3), (4 + 5), add(6, (7 * 8)))",
+		},
+		{
+			input:    "add(a + b + c * d / f + g);",
+			expected: "add((((a + b) + ((c * d) / f)) + g))",
+		},
+		{
+			input:    "-add(x)(y);",
+			expected: "(-add(x)(y))",
+		},
+		{
+			input:    "a + b % c * d;",
+			expected: "(a + ((b % c) * d))",
+		},
+		{
+			input:    "a <= b == c >= d;",
+			expected: "((a <= b) == (c >= d))",
+		},
+		{
+			input:    "a || b && c;",
+			expected: "(a || (b && c))",
+		},
+		{
+			input:    "a && b || c && d;",
+			expected: "((a && b) || (c && d))",
+		},
+		{
+			input:    "a == b && c != d || !e;",
+			expected: "(((a == b) && (c != d)) || (!e))",
+		},
+		{
+			input:    "x < 1 + 2 && y >= 3 * 4;",
+			expected: "((x < (1 + 2)) && (y >= (3 * 4)))",
+		},
+		{
+			input:    "(a || b) && c;",
+			expected: "((a || b) && c)",
+		},
+		{
+			input:    "a | b & c;",
+			expected: "(a | (b & c))",
+		},
+		{
+			input:    "a ^ b << 2;",
+			expected: "(a ^ (b << 2))",
+		},
+		{
+			input:    "1 << 2 + 3;",
+			expected: "((1 << 2) + 3)",
+		},
+		{
+			input:    "a & b == c;",
+			expected: "((a & b) == c)",
+		},
+		{
+			input:    "~a & b >> 1;",
+			expected: "(((~a) & b) >> 1)",
+		},
+		{
+			input:    "a | b && c ^ d;",
+			expected: "((a | b) && (c ^ d))",
+		},
+		{
+			input:    "1.5 + 2 * 3.0;",
+			expected: "(1.5 + (2 * 3.0))",
+		},
+		{
+			input:    "-1.5e3 * 2 - 4 / 0.5;",
+			expected: "(((-1.5e3) * 2) - (4 / 0.5))",
+		},
+		{
+			input:    "(1 + 2.5) * 3 > 2.0 == true;",
+			expected: "((((1 + 2.5) * 3) > 2.0) == true)",
+		},
+		{
+			input:    "a * [1, 2, 3, 4][b * c] * d;",
+			expected: "((a * ([1, 2, 3, 4][(b * c)])) * d)",
+		},
+		{
+			input:    "add(a * b[2], b[1], 2 * [1, 2][1]);",
+			expected: "add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))",
+		},
+		{
+			input:    "-a[0];",
+			expected: "(-(a[0]))",
+		},
+		{
+			input:    "f(x)[0];",
+			expected: "(f(x)[0])",
+		},
 	}
 
 	for _, tc := range tests {
@@ -404,6 +815,1107 @@ func testIntegerLiteral(t *testing.T, il ast.Expression, value int64) bool {
 	return true
 }
 
+func TestParseBooleanExpressions(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedBoolean bool
+	}{
+		{"true;", true},
+		{"false;", false},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", program.Statements[0])
+		}
+
+		if !testBooleanLiteral(t, stmt.Expression, tc.expectedBoolean) {
+			return
+		}
+	}
+}
+
+func TestParseUnclosedGroupedExpression(t *testing.T) {
+	l := lexer.New("(1 + 2;")
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 parser error. got: %d instead", len(errors))
+	}
+
+	if errors[0].Expected != token.RPAREN {
+		t.Errorf("errors[0].Expected is not %q. got: %q instead", token.RPAREN, errors[0].Expected)
+	}
+}
+
+func TestParseIfExpression(t *testing.T) {
+	input := `if (x < y) { x }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", program.Statements[0])
+	}
+
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.IfExpression type. got: %T instead", stmt.Expression)
+	}
+
+	if !testInfixExpression(t, exp.Condition, "x", "<", "y") {
+		return
+	}
+
+	if !testBlockStatement(t, exp.Consequence, "x") {
+		return
+	}
+
+	if len(exp.ElseIfs) != 0 {
+		t.Errorf("exp.ElseIfs expected to be empty. got: %d branches instead", len(exp.ElseIfs))
+	}
+
+	if exp.Alternative != nil {
+		t.Errorf("exp.Alternative expected to be nil. got: %+v instead", exp.Alternative)
+	}
+}
+
+func TestParseIfElseExpression(t *testing.T) {
+	input := `if (x < y) { x } else { y }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", program.Statements[0])
+	}
+
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.IfExpression type. got: %T instead", stmt.Expression)
+	}
+
+	if !testInfixExpression(t, exp.Condition, "x", "<", "y") {
+		return
+	}
+
+	if !testBlockStatement(t, exp.Consequence, "x") {
+		return
+	}
+
+	if !testBlockStatement(t, exp.Alternative, "y") {
+		return
+	}
+}
+
+func TestParseElseIfExpressions(t *testing.T) {
+	inputs := []string{
+		`if (x < y) { x } else if (x > y) { y } elseif (x == 1) { 1 } else { z }`,
+		`if (x < y) { x }
+		else
+		if (x > y) { y } elseif (x == 1) { 1 }
+		else { z }`,
+	}
+
+	for _, input := range inputs {
+		l := lexer.New(input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", program.Statements[0])
+		}
+
+		exp, ok := stmt.Expression.(*ast.IfExpression)
+		if !ok {
+			t.Fatalf("expression is not a valid *ast.IfExpression type. got: %T instead", stmt.Expression)
+		}
+
+		if !testBlockStatement(t, exp.Consequence, "x") {
+			return
+		}
+
+		if len(exp.ElseIfs) != 2 {
+			t.Fatalf("exp.ElseIfs expected to contain 2 branches. got: %d instead", len(exp.ElseIfs))
+		}
+
+		if !testInfixExpression(t, exp.ElseIfs[0].Condition, "x", ">", "y") {
+			return
+		}
+
+		if !testBlockStatement(t, exp.ElseIfs[0].Consequence, "y") {
+			return
+		}
+
+		if !testInfixExpression(t, exp.ElseIfs[1].Condition, "x", "==", 1) {
+			return
+		}
+
+		if !testBlockStatement(t, exp.ElseIfs[1].Consequence, 1) {
+			return
+		}
+
+		if !testBlockStatement(t, exp.Alternative, "z") {
+			return
+		}
+
+		expected := "if(x < y) x elseif(x > y) y elseif(x == 1) 1 else z"
+		if exp.String() != expected {
+			t.Errorf("exp.String() is not %q. got: %q instead", expected, exp.String())
+		}
+	}
+}
+
+func TestParseUnterminatedIfExpression(t *testing.T) {
+	tests := []string{
+		"if (x < y) { x",
+		"if (x < y) { x } else { y",
+		"if (x < y) { x } elseif { y }",
+		"if x < y { x }",
+	}
+
+	for _, input := range tests {
+		l := lexer.New(input)
+		p := New(l)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parser errors for %q. got none", input)
+		}
+	}
+}
+
+func TestParseFunctionLiteral(t *testing.T) {
+	input := `fn(x, y) { x + y; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", program.Statements[0])
+	}
+
+	function, ok := stmt.Expression.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.FunctionLiteral type. got: %T instead", stmt.Expression)
+	}
+
+	if len(function.Parameters) != 2 {
+		t.Fatalf("function.Parameters expected to contain 2 parameters. got: %d instead", len(function.Parameters))
+	}
+
+	testLiteralExpression(t, function.Parameters[0], "x")
+	testLiteralExpression(t, function.Parameters[1], "y")
+
+	if len(function.Body.Statements) != 1 {
+		t.Fatalf("function.Body.Statements expected to contain 1 statement. got: %d instead", len(function.Body.Statements))
+	}
+
+	bodyStmt, ok := function.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("function.Body.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", function.Body.Statements[0])
+	}
+
+	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
+}
+
+func TestParseFunctionParameters(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+	}{
+		{input: "fn() {};", expectedParams: []string{}},
+		{input: "fn(x) {};", expectedParams: []string{"x"}},
+		{input: "fn(x, y, z) {};", expectedParams: []string{"x", "y", "z"}},
+		{input: "fn(x, y,) {};", expectedParams: []string{"x", "y"}},
+		{input: "fn(x) { return };", expectedParams: []string{"x"}},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		function := stmt.Expression.(*ast.FunctionLiteral)
+
+		if len(function.Parameters) != len(tc.expectedParams) {
+			t.Errorf("function.Parameters expected to contain %d parameters. got: %d instead", len(tc.expectedParams), len(function.Parameters))
+		}
+
+		for i, ident := range tc.expectedParams {
+			testLiteralExpression(t, function.Parameters[i], ident)
+		}
+	}
+}
+
+func TestParseCallExpression(t *testing.T) {
+	input := "add(1, 2 * 3, 4 + 5,);"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", program.Statements[0])
+	}
+
+	exp, ok := stmt.Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.CallExpression type. got: %T instead", stmt.Expression)
+	}
+
+	if !testIdentifier(t, exp.Function, "add") {
+		return
+	}
+
+	if len(exp.Arguments) != 3 {
+		t.Fatalf("exp.Arguments expected to contain 3 arguments. got: %d instead", len(exp.Arguments))
+	}
+
+	testLiteralExpression(t, exp.Arguments[0], 1)
+	testInfixExpression(t, exp.Arguments[1], 2, "*", 3)
+	testInfixExpression(t, exp.Arguments[2], 4, "+", 5)
+}
+
+func TestParseImmediatelyInvokedFunction(t *testing.T) {
+	input := "fn(x){x}(5);"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+	exp, ok := stmt.Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.CallExpression type. got: %T instead", stmt.Expression)
+	}
+
+	function, ok := exp.Function.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("exp.Function is not a valid *ast.FunctionLiteral type. got: %T instead", exp.Function)
+	}
+
+	if len(function.Parameters) != 1 || !testIdentifier(t, function.Parameters[0], "x") {
+		t.Fatalf("function.Parameters expected to be [x]. got: %v instead", function.Parameters)
+	}
+
+	if len(exp.Arguments) != 1 || !testIntegerLiteral(t, exp.Arguments[0], 5) {
+		t.Fatalf("exp.Arguments expected to be [5]. got: %v instead", exp.Arguments)
+	}
+
+	if program.String() != "fn(x) x(5)" {
+		t.Errorf("program.String() is not %q. got: %q instead", "fn(x) x(5)", program.String())
+	}
+}
+
+func TestParseMalformedCallExpressions(t *testing.T) {
+	tests := []string{
+		"add(1, 2",
+		"add(,)",
+		"fn(x, 1) {}",
+		"fn(x {}",
+	}
+
+	for _, input := range tests {
+		l := lexer.New(input)
+		p := New(l)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parser errors for %q. got none", input)
+		}
+	}
+}
+
+func TestParseArrayLiteral(t *testing.T) {
+	input := "[1, 2 * 2, 3 + 3,];"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", program.Statements[0])
+	}
+
+	array, ok := stmt.Expression.(*ast.ArrayLiteral)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.ArrayLiteral type. got: %T instead", stmt.Expression)
+	}
+
+	if len(array.Elements) != 3 {
+		t.Fatalf("array.Elements expected to contain 3 elements. got: %d instead", len(array.Elements))
+	}
+
+	testIntegerLiteral(t, array.Elements[0], 1)
+	testInfixExpression(t, array.Elements[1], 2, "*", 2)
+	testInfixExpression(t, array.Elements[2], 3, "+", 3)
+}
+
+func TestParseEmptyArrayLiteral(t *testing.T) {
+	l := lexer.New("[]")
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+	array, ok := stmt.Expression.(*ast.ArrayLiteral)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.ArrayLiteral type. got: %T instead", stmt.Expression)
+	}
+
+	if len(array.Elements) != 0 {
+		t.Errorf("array.Elements expected to be empty. got: %d elements instead", len(array.Elements))
+	}
+}
+
+func TestParseIndexExpression(t *testing.T) {
+	input := "myArray[1 + 1]"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+	exp, ok := stmt.Expression.(*ast.IndexExpression)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.IndexExpression type. got: %T instead", stmt.Expression)
+	}
+
+	if !testIdentifier(t, exp.Left, "myArray") {
+		return
+	}
+
+	testInfixExpression(t, exp.Index, 1, "+", 1)
+}
+
+func TestParseHashLiteral(t *testing.T) {
+	input := `{"one": 1, "two": 2, "three": 3,}`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+	hash, ok := stmt.Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.HashLiteral type. got: %T instead", stmt.Expression)
+	}
+
+	expected := []struct {
+		key   string
+		value int64
+	}{
+		{"one", 1},
+		{"two", 2},
+		{"three", 3},
+	}
+
+	if len(hash.Pairs) != len(expected) {
+		t.Fatalf("hash.Pairs expected to contain %d pairs. got: %d instead", len(expected), len(hash.Pairs))
+	}
+
+	for i, tc := range expected {
+		key, ok := hash.Pairs[i].Key.(*ast.StringLiteral)
+		if !ok {
+			t.Errorf("hash.Pairs[%d].Key is not *ast.StringLiteral. got: %T instead", i, hash.Pairs[i].Key)
+		} else if key.Value != tc.key {
+			t.Errorf("key.Value is not %q. got: %q instead", tc.key, key.Value)
+		}
+
+		testIntegerLiteral(t, hash.Pairs[i].Value, tc.value)
+	}
+}
+
+func TestParseEmptyHashLiteral(t *testing.T) {
+	l := lexer.New("{}")
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+	hash, ok := stmt.Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.HashLiteral type. got: %T instead", stmt.Expression)
+	}
+
+	if len(hash.Pairs) != 0 {
+		t.Errorf("hash.Pairs expected to be empty. got: %d pairs instead", len(hash.Pairs))
+	}
+}
+
+func TestParseNestedLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    `[[1, 2], [3, [4]],]`,
+			expected: "(array (array 1 2) (array 3 (array 4)))",
+		},
+		{
+			input:    `{"a": [1, {"b": 2}], 1 + 1: {}}`,
+			expected: `(hash (pair "a" (array 1 (hash (pair "b" 2)))) (pair (+ 1 1) (hash)))`,
+		},
+		{
+			input:    `m["a"][0]["b"]`,
+			expected: `(index (index (index m "a") 0) "b")`,
+		},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		output := ast.SExpr(program)
+		if output != tc.expected {
+			t.Errorf("expected %s, got %s instead", tc.expected, output)
+		}
+	}
+}
+
+func TestParseMalformedCollectionLiterals(t *testing.T) {
+	tests := []string{
+		"[1, 2",
+		"[,]",
+		"a[1",
+		"a[]",
+		`{"a" 1}`,
+		`{"a": 1`,
+		`{"a": 1 "b": 2}`,
+	}
+
+	for _, input := range tests {
+		l := lexer.New(input)
+		p := New(l)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parser errors for %q. got none", input)
+		}
+	}
+}
+
+func TestParseFloatLiteralExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14;", 3.14},
+		{"1e9;", 1e9},
+		{"1.5e-3;", 1.5e-3},
+		{"2E+2;", 200},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+		if !testFloatLiteral(t, stmt.Expression, tc.expected) {
+			return
+		}
+	}
+}
+
+func TestParseInfixExpressionsWithFloats(t *testing.T) {
+	tests := []struct {
+		input      string
+		leftValue  interface{}
+		operator   string
+		rightValue interface{}
+	}{
+		{"1.5 + 2;", 1.5, "+", 2},
+		{"2 * 0.25;", 2, "*", 0.25},
+		{"1e3 < 1.5e3;", 1e3, "<", 1.5e3},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+		if !testInfixExpression(t, stmt.Expression, tc.leftValue, tc.operator, tc.rightValue) {
+			return
+		}
+	}
+}
+
+func TestParseStringLiteralExpression(t *testing.T) {
+	input := `"hello\t\"world\"";`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+	literal, ok := stmt.Expression.(*ast.StringLiteral)
+	if !ok {
+		t.Fatalf("expression is not a valid *ast.StringLiteral type. got: %T instead", stmt.Expression)
+	}
+
+	if literal.Value != "hello\t\"world\"" {
+		t.Errorf("literal.Value is not %q. got: %q instead", "hello\t\"world\"", literal.Value)
+	}
+
+	if literal.String() != `"hello\t\"world\""` {
+		t.Errorf("literal.String() is not the quoted source text. got: %q instead", literal.String())
+	}
+}
+
+func TestParseAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x = 5;", "(= x 5)"},
+		{"x += 1 + 2;", "(+= x (+ 1 2))"},
+		{"x -= 1", "(-= x 1)"},
+		{"x *= y || z", "(*= x (|| y z))"},
+		{"x /= 2", "(/= x 2)"},
+		{"a = b = c;", "(= a (= b c))"},
+		{"a += b -= 1;", "(+= a (-= b 1))"},
+		{"arr[0] = 1;", "(= (index arr 0) 1)"},
+		{`m["a"][1] += 2;`, `(+= (index (index m "a") 1) 2)`},
+		{"let y = x = 2;", "(let y (= x 2))"},
+		{"f(x = 1);", "(call f (= x 1))"},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		output := ast.SExpr(program)
+		if output != tc.expected {
+			t.Errorf("expected %s, got %s instead", tc.expected, output)
+		}
+	}
+}
+
+func TestParseInvalidAssignmentTargets(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedMsg string
+		column      int
+	}{
+		{"5 = x;", "cannot assign to 5", 3},
+		{"a + b = c;", "cannot assign to (a + b)", 7},
+		{"f() += 1;", "cannot assign to f()", 5},
+		{`"s" = 1;`, `cannot assign to "s"`, 5},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("%s: expected parser errors. got none", tc.input)
+		}
+
+		if errors[0].Kind != InvalidAssignment {
+			t.Errorf("%s: errors[0].Kind is not %s. got: %s instead", tc.input, InvalidAssignment, errors[0].Kind)
+		}
+
+		if errors[0].Msg != tc.expectedMsg {
+			t.Errorf("%s: errors[0].Msg is not %q. got: %q instead", tc.input, tc.expectedMsg, errors[0].Msg)
+		}
+
+		if errors[0].Pos.Column != tc.column {
+			t.Errorf("%s: errors[0].Pos.Column is not %d. got: %d instead", tc.input, tc.column, errors[0].Pos.Column)
+		}
+	}
+}
+
+func TestParseWhileStatement(t *testing.T) {
+	input := "while (x < 10) { x += 1; }"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.WhileStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not a valid *ast.WhileStatement type. got: %T instead", program.Statements[0])
+	}
+
+	if !testInfixExpression(t, stmt.Condition, "x", "<", 10) {
+		return
+	}
+
+	if len(stmt.Body.Statements) != 1 {
+		t.Fatalf("stmt.Body.Statements expected to contain 1 statement. got: %d instead", len(stmt.Body.Statements))
+	}
+
+	if output := ast.SExpr(stmt.Body); output != "(block (+= x 1))" {
+		t.Errorf("stmt.Body is not %q. got: %q instead", "(block (+= x 1))", output)
+	}
+}
+
+func TestParseForStatement(t *testing.T) {
+	input := "for (item in [1, 2]) { item; };"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements expected to contain 1 statement. but got: %d instead", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ForStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not a valid *ast.ForStatement type. got: %T instead", program.Statements[0])
+	}
+
+	if !testIdentifier(t, stmt.Variable, "item") {
+		return
+	}
+
+	if _, ok := stmt.Iterable.(*ast.ArrayLiteral); !ok {
+		t.Fatalf("stmt.Iterable is not a valid *ast.ArrayLiteral type. got: %T instead", stmt.Iterable)
+	}
+
+	testBlockStatement(t, stmt.Body, "item")
+}
+
+func TestParseLoopControlStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "while (true) { break; }",
+			expected: "(while true (block (break)))",
+		},
+		{
+			input:    "for (x in xs) { if (x) { continue } x }",
+			expected: "(for x xs (block (if x (block (continue))) x))",
+		},
+		{
+			input:    "while (a) { for (b in c) { break } continue; }",
+			expected: "(while a (block (for b c (block (break))) (continue)))",
+		},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		output := ast.SExpr(program)
+		if output != tc.expected {
+			t.Errorf("expected %s, got %s instead", tc.expected, output)
+		}
+	}
+}
+
+func TestParseMalformedLoops(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedMsg string
+	}{
+		{"break;", "break statement outside of a loop"},
+		{"if (x) { continue }", "continue statement outside of a loop"},
+		{"while (x) { fn() { break } }", "break statement outside of a loop"},
+		{"while (if (true) { break } else { false }) { 1 }", "break statement outside of a loop"},
+		{"while x { }", "expected next token to be (, got: IDENT instead"},
+		{"for (x of xs) { }", "expected next token to be IN, got: IDENT instead"},
+		{"for (1 in xs) { }", "expected next token to be IDENT, got: INT instead"},
+		{"for (x in xs { }", "expected next token to be ), got: { instead"},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("%s: expected parser errors. got none", tc.input)
+		}
+
+		if errors[0].Msg != tc.expectedMsg {
+			t.Errorf("%s: errors[0].Msg is not %q. got: %q instead", tc.input, tc.expectedMsg, errors[0].Msg)
+		}
+	}
+}
+
+func TestNodeSpans(t *testing.T) {
+	input := `let total = -a + b * 3;
+return  x;
+return;
+foo(1, 2)[0];
+if (x) { y } else { z }
+let s = "héllo" + s;
+(1 + 2) * 3;
+-(a);
+x = (y);
+let x = (5);
+`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	checkParserErrors(t, p)
+
+	let := program.Statements[0].(*ast.LetStatement)
+	infix := let.Value.(*ast.InfixExpression)
+	prefix := infix.Left.(*ast.PrefixExpression)
+	product := infix.Right.(*ast.InfixExpression)
+	ret := program.Statements[1].(*ast.ReturnStatement)
+	bareReturn := program.Statements[2].(*ast.ReturnStatement)
+	index := program.Statements[3].(*ast.ExpressionStatement)
+	call := index.Expression.(*ast.IndexExpression).Left
+	ifExp := program.Statements[4].(*ast.ExpressionStatement)
+	str := program.Statements[5].(*ast.LetStatement).Value.(*ast.InfixExpression).Left
+	grouped := program.Statements[6].(*ast.ExpressionStatement)
+	sum := grouped.Expression.(*ast.InfixExpression).Left
+	negated := program.Statements[7].(*ast.ExpressionStatement).Expression
+	assign := program.Statements[8].(*ast.ExpressionStatement).Expression
+	parenLet := program.Statements[9].(*ast.LetStatement)
+
+	tests := []struct {
+		node     ast.Node
+		expected string
+	}{
+		{let, "let total = -a + b * 3"},
+		{let.Name, "total"},
+		{infix, "-a + b * 3"},
+		{prefix, "-a"},
+		{prefix.Right, "a"},
+		{product, "b * 3"},
+		{product.Right, "3"},
+		{ret, "return  x"},
+		{ret.ReturnValue, "x"},
+		{bareReturn, "return"},
+		{index, "foo(1, 2)[0]"},
+		{call, "foo(1, 2)"},
+		{ifExp, "if (x) { y } else { z }"},
+		{str, `"héllo"`},
+		{grouped, "(1 + 2) * 3"},
+		{sum, "(1 + 2)"},
+		{sum.(*ast.ParenExpression).Expression, "1 + 2"},
+		{negated, "-(a)"},
+		{assign, "x = (y)"},
+		{parenLet, "let x = (5)"},
+		{program, input[:len(input)-2]},
+	}
+
+	for i, tc := range tests {
+		pos, end := tc.node.Pos(), tc.node.End()
+
+		if !pos.IsValid() || !end.IsValid() {
+			t.Errorf("tests[%d] - invalid span %s-%s", i, pos, end)
+			continue
+		}
+
+		if got := input[pos.Offset:end.Offset]; got != tc.expected {
+			t.Errorf("tests[%d] - wrong span text. expected=%q, got=%q", i, tc.expected, got)
+		}
+	}
+
+	if str.End().RuneColumn-str.Pos().RuneColumn != 7 {
+		t.Errorf("str span expected to be 7 runes wide. got: %s-%s instead", str.Pos(), str.End())
+	}
+}
+
+func TestParseBadAssignmentTarget(t *testing.T) {
+	l := lexer.New("@ = 1;")
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 parser error. got: %d instead: %s", len(errors), errors)
+	}
+
+	if errors[0].Kind != IllegalToken {
+		t.Errorf("errors[0].Kind is not %s. got: %s instead", IllegalToken, errors[0].Kind)
+	}
+}
+
+func TestParseIllegalTokens(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedMsg string
+	}{
+		{`let s = "unterminated;`, "unterminated string literal"},
+		{`let s = "bad \q escape";`, `invalid escape sequence "\q" in string literal`},
+		{`5 @ 3;`, `illegal character '@'`},
+	}
+
+	for _, tc := range tests {
+		l := lexer.New(tc.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("%s: expected parser errors. got none", tc.input)
+		}
+
+		if errors[0].Kind != IllegalToken {
+			t.Errorf("%s: errors[0].Kind is not %s. got: %s instead", tc.input, IllegalToken, errors[0].Kind)
+		}
+
+		if errors[0].Msg != tc.expectedMsg {
+			t.Errorf("%s: errors[0].Msg is not %q. got: %q instead", tc.input, tc.expectedMsg, errors[0].Msg)
+		}
+	}
+}
+
+func TestParseSkipsComments(t *testing.T) {
+	input := `
+	// add two numbers
+	let x = 5 /* five */ + 10;
+	`
+
+	for _, l := range []*lexer.Lexer{lexer.New(input), lexer.New(input, lexer.WithComments())} {
+		p := New(l)
+		program := p.ParseProgram()
+
+		checkParserErrors(t, p)
+
+		if program.String() != "let x = (5 + 10);" {
+			t.Errorf("program.String() is not %q. got: %q instead", "let x = (5 + 10);", program.String())
+		}
+	}
+}
+
+func testBlockStatement(t *testing.T, block *ast.BlockStatement, expected interface{}) bool {
+	if block == nil {
+		t.Errorf("block is nil")
+
+		return false
+	}
+
+	if len(block.Statements) != 1 {
+		t.Errorf("block.Statements expected to contain 1 statement. got: %d instead", len(block.Statements))
+
+		return false
+	}
+
+	stmt, ok := block.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Errorf("block.Statements[0] is not a valid *ast.ExpressionStatement type. got: %T instead", block.Statements[0])
+
+		return false
+	}
+
+	return testLiteralExpression(t, stmt.Expression, expected)
+}
+
+func testIdentifier(t *testing.T, exp ast.Expression, value string) bool {
+	ident, ok := exp.(*ast.Identifier)
+	if !ok {
+		t.Errorf("exp is not *ast.Identifier. got: %T instead", exp)
+
+		return false
+	}
+
+	if ident.Value != value {
+		t.Errorf("ident.Value is not %s. got: %s instead", value, ident.Value)
+
+		return false
+	}
+
+	if ident.TokenLiteral() != value {
+		t.Errorf("ident.TokenLiteral() is not %s. got: %s instead", value, ident.TokenLiteral())
+
+		return false
+	}
+
+	return true
+}
+
+func testLiteralExpression(t *testing.T, exp ast.Expression, expected interface{}) bool {
+	switch v := expected.(type) {
+	case int:
+		return testIntegerLiteral(t, exp, int64(v))
+	case int64:
+		return testIntegerLiteral(t, exp, v)
+	case string:
+		return testIdentifier(t, exp, v)
+	case float64:
+		return testFloatLiteral(t, exp, v)
+	case bool:
+		return testBooleanLiteral(t, exp, v)
+	}
+
+	t.Errorf("type of exp not handled. got: %T", exp)
+
+	return false
+}
+
+func testFloatLiteral(t *testing.T, exp ast.Expression, value float64) bool {
+	floatLit, ok := exp.(*ast.FloatLiteral)
+	if !ok {
+		t.Errorf("exp is not *ast.FloatLiteral. got: %T instead", exp)
+
+		return false
+	}
+
+	if floatLit.Value != value {
+		t.Errorf("floatLit.Value is not %g. got: %g instead", value, floatLit.Value)
+
+		return false
+	}
+
+	return true
+}
+
+func testBooleanLiteral(t *testing.T, exp ast.Expression, value bool) bool {
+	boolean, ok := exp.(*ast.Boolean)
+	if !ok {
+		t.Errorf("exp is not *ast.Boolean. got: %T instead", exp)
+
+		return false
+	}
+
+	if boolean.Value != value {
+		t.Errorf("boolean.Value is not %t. got: %t instead", value, boolean.Value)
+
+		return false
+	}
+
+	if boolean.TokenLiteral() != fmt.Sprintf("%t", value) {
+		t.Errorf("boolean.TokenLiteral() is not %t. got: %s instead", value, boolean.TokenLiteral())
+
+		return false
+	}
+
+	return true
+}
+
+func testInfixExpression(t *testing.T, exp ast.Expression, left interface{}, operator string, right interface{}) bool {
+	opExp, ok := exp.(*ast.InfixExpression)
+	if !ok {
+		t.Errorf("exp is not *ast.InfixExpression. got: %T(%s) instead", exp, exp)
+
+		return false
+	}
+
+	if !testLiteralExpression(t, opExp.Left, left) {
+		return false
+	}
+
+	if opExp.Operator != operator {
+		t.Errorf("exp.Operator is not '%s'. got: %q instead", operator, opExp.Operator)
+
+		return false
+	}
+
+	if !testLiteralExpression(t, opExp.Right, right) {
+		return false
+	}
+
+	return true
+}
+
 func checkParserErrors(t *testing.T, p *Parser) {
 	errors := p.errors
 	if len(errors) == 0 {
@@ -4,20 +4,51 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/mycok/monkey_interpreter/ast"
+	"github.com/mycok/monkey_interpreter/diagnostic"
+	"github.com/mycok/monkey_interpreter/evaluator"
 	"github.com/mycok/monkey_interpreter/lexer"
+	"github.com/mycok/monkey_interpreter/object"
+	"github.com/mycok/monkey_interpreter/parser"
 	"github.com/mycok/monkey_interpreter/token"
 )
 
 const prompt = ":: "
 
-// Start displays a user prompt message and initializes a scanner object to read user
-// input from the stdOut.
+// mode determines what the REPL displays for every line of input.
+type mode int
+
+const (
+	modeEval   mode = iota // Evaluate the line and display the result.
+	modeTokens             // Display the token stream of the line.
+	modeAST                // Display the String() form of the parsed line.
+	modeSExpr              // Display the fully parenthesised form of the parsed line.
+)
+
+// commands maps the REPL meta-commands to the modes they switch to.
+var commands = map[string]mode{
+	":eval":   modeEval,
+	":tokens": modeTokens,
+	":ast":    modeAST,
+	":sexpr":  modeSExpr,
+}
+
+// Start displays a user prompt message and reads user input from in line by line.
+// Every line is evaluated within the same environment so that bindings persist between
+// lines. All output, including parser errors, is written to out.
+//
+// Lines starting with a colon are treated as meta-commands that switch what is displayed
+// for subsequent lines: ":eval" (default), ":tokens", ":ast" and ":sexpr".
 func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
+	renderer := diagnostic.New(out)
+	env := object.NewEnvironment()
+	current := modeEval
 
 	for {
-		fmt.Printf(prompt)
+		fmt.Fprint(out, prompt)
 		scanned := scanner.Scan()
 
 		if !scanned {
@@ -25,10 +56,48 @@ func Start(in io.Reader, out io.Writer) {
 		}
 
 		line := scanner.Text()
-		l := lexer.New(line)
 
-		for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
-			fmt.Printf("%+v\n", tok)
+		if strings.HasPrefix(strings.TrimSpace(line), ":") {
+			cmd := strings.TrimSpace(line)
+
+			m, ok := commands[cmd]
+			if !ok {
+				fmt.Fprintf(out, "unknown command %q. available commands: :eval, :tokens, :ast, :sexpr\n", cmd)
+				continue
+			}
+
+			current = m
+			continue
+		}
+
+		if current == modeTokens {
+			l := lexer.New(line, lexer.WithComments())
+
+			for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+				fmt.Fprintf(out, "%+v\n", tok)
+			}
+
+			continue
+		}
+
+		p := parser.New(lexer.New(line))
+		program := p.ParseProgram()
+
+		if len(p.Errors()) != 0 {
+			renderer.Render(line, p.Errors())
+			continue
+		}
+
+		switch current {
+		case modeAST:
+			fmt.Fprintln(out, program.String())
+		case modeSExpr:
+			fmt.Fprintln(out, ast.SExpr(program))
+		default:
+			evaluated := evaluator.Eval(program, env)
+			if evaluated != nil {
+				fmt.Fprintln(out, evaluated.Inspect())
+			}
 		}
 	}
 }
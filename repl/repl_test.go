@@ -0,0 +1,70 @@
+package repl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "eval",
+			input:    "let x = 5;\nx * 2\n",
+			expected: ":: :: 10\n:: ",
+		},
+		{
+			name:     "tokens",
+			input:    ":tokens\nx;\n",
+			expected: ":: :: {Type:IDENT Literal:x Pos:1:1}\n{Type:; Literal:; Pos:1:2}\n:: ",
+		},
+		{
+			name:     "ast",
+			input:    ":ast\nlet x = 1 + 2 * 3;\n",
+			expected: ":: :: let x = (1 + (2 * 3));\n:: ",
+		},
+		{
+			name:     "sexpr",
+			input:    ":sexpr\nlet x = -1 + 2 * 3; if (x) { f(x) } else { x }\n",
+			expected: ":: :: (let x (+ (- 1) (* 2 3))) (if x (block (call f x)) (block x))\n:: ",
+		},
+		{
+			name:     "switch back to eval",
+			input:    ":ast\n:eval\n1 + 2\n",
+			expected: ":: :: :: 3\n:: ",
+		},
+		{
+			name:     "unknown command",
+			input:    ":foo\n",
+			expected: ":: unknown command \":foo\". available commands: :eval, :tokens, :ast, :sexpr\n:: ",
+		},
+	}
+
+	for _, tc := range tests {
+		var out bytes.Buffer
+
+		Start(strings.NewReader(tc.input), &out)
+
+		if out.String() != tc.expected {
+			t.Errorf("%s: wrong output. expected: %q, got: %q instead", tc.name, tc.expected, out.String())
+		}
+	}
+}
+
+func TestStartParserErrors(t *testing.T) {
+	var out bytes.Buffer
+
+	Start(strings.NewReader("let x 5;\n"), &out)
+
+	if !strings.Contains(out.String(), "expected next token to be =, got: INT instead") {
+		t.Errorf("parser error not written to out. got: %q", out.String())
+	}
+
+	if !strings.Contains(out.String(), "1 | let x 5;") {
+		t.Errorf("source snippet not written to out. got: %q", out.String())
+	}
+}
@@ -1,5 +1,11 @@
 package token
 
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
 const (
 	// ILLEGAL represents any character not understood by the language lexer.
 	ILLEGAL = "ILLEGAL"
@@ -13,6 +19,16 @@ const (
 	// INT such as 1234567890
 	INT = "INT"
 
+	// FLOAT such as 3.14, 1e9 or 1.5e-3
+	FLOAT = "FLOAT"
+
+	// STRING such as "foobar"
+	STRING = "STRING"
+
+	// COMMENT such as // note or /* note */. Only produced when the lexer is configured
+	// to retain comments.
+	COMMENT = "COMMENT"
+
 	// ASSIGN ... are some of the operators implemented in the language.
 	ASSIGN   = "="
 	PLUS     = "+"
@@ -20,19 +36,38 @@ const (
 	BANG     = "!"
 	ASTERISK = "*"
 	SLASH    = "/"
+	MODULO   = "%"
 	LT       = "<"
 	GT       = ">"
+	LTE      = "<="
+	GTE      = ">="
 	EQ       = "=="
 	NOTEQ    = "!="
+	AND      = "&&"
+	OR       = "||"
+	BITAND   = "&"
+	BITOR    = "|"
+	BITXOR   = "^"
+	BITNOT   = "~"
+	SHL      = "<<"
+	SHR      = ">>"
+
+	PLUS_ASSIGN     = "+="
+	MINUS_ASSIGN    = "-="
+	ASTERISK_ASSIGN = "*="
+	SLASH_ASSIGN    = "/="
 
 	// COMMA ... are some of the delimiters implemented in the language.
 	COMMA     = ","
 	SEMICOLON = ";"
+	COLON     = ":"
 
-	LPAREN = "("
-	RPAREN = ")"
-	LBRACE = "{"
-	RBRACE = "}"
+	LPAREN   = "("
+	RPAREN   = ")"
+	LBRACE   = "{"
+	RBRACE   = "}"
+	LBRACKET = "["
+	RBRACKET = "]"
 
 	// FUNCTION ... are some of the keywords implemented in the language.
 	FUNCTION = "FUNCTION"
@@ -43,17 +78,27 @@ const (
 	RETURN   = "RETURN"
 	TRUE     = "TRUE"
 	FALSE    = "FALSE"
+	WHILE    = "WHILE"
+	FOR      = "FOR"
+	IN       = "IN"
+	BREAK    = "BREAK"
+	CONTINUE = "CONTINUE"
 )
 
 var keywords = map[string]TokenType{
-	"fn":     FUNCTION,
-	"let":    LET,
-	"if":     IF,
-	"else":   ELSE,
-	"elseif": ELSEIF,
-	"return": RETURN,
-	"true":   TRUE,
-	"false":  FALSE,
+	"fn":       FUNCTION,
+	"let":      LET,
+	"if":       IF,
+	"else":     ELSE,
+	"elseif":   ELSEIF,
+	"return":   RETURN,
+	"true":     TRUE,
+	"false":    FALSE,
+	"while":    WHILE,
+	"for":      FOR,
+	"in":       IN,
+	"break":    BREAK,
+	"continue": CONTINUE,
 }
 
 // TokenType represents the type of a token.
@@ -63,6 +108,64 @@ type TokenType string
 type Token struct {
 	Type    TokenType
 	Literal string
+	Pos     Pos // Position of the first character of the token in the input.
+}
+
+// End returns the position immediately after the last character of the token.
+func (t Token) End() Pos { return t.Pos.Advance(t.Literal) }
+
+// Pos represents a source position of a token in the input.
+type Pos struct {
+	Filename   string
+	Offset     int // Byte offset, starting at 0.
+	Line       int // Line number, starting at 1.
+	Column     int // Column number, starting at 1 (byte count).
+	RuneColumn int // Column number, starting at 1 (rune count).
+}
+
+// Advance returns the position immediately after s when s starts at p.
+func (p Pos) Advance(s string) Pos {
+	p.Offset += len(s)
+
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		p.Line += strings.Count(s, "\n")
+		p.Column = len(s) - i
+		p.RuneColumn = utf8.RuneCountInString(s[i+1:]) + 1
+
+		return p
+	}
+
+	p.Column += len(s)
+	p.RuneColumn += utf8.RuneCountInString(s)
+
+	return p
+}
+
+// IsValid reports whether the position is valid.
+func (p Pos) IsValid() bool { return p.Line > 0 }
+
+// String returns a string representation of the position in one of the following forms.
+//
+//	file:line:column    valid position with filename
+//	line:column         valid position without filename
+//	file                invalid position with filename
+//	-                   invalid position without filename
+func (p Pos) String() string {
+	s := p.Filename
+
+	if p.IsValid() {
+		if s != "" {
+			s += ":"
+		}
+
+		s += fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+
+	if s == "" {
+		s = "-"
+	}
+
+	return s
 }
 
 // LookupIndentifier performs a map lookup based on the provided identifier string and